@@ -0,0 +1,331 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	bufWorkFileName   = "buf.work.yaml"
+	bufModuleFileName = "buf.yaml"
+	bufLockFileName   = "buf.lock"
+	bufDefaultRemote  = "buf.build"
+
+	bufDigestTypeB4       = "b4"
+	bufDigestTypeB5       = "b5"
+	bufDigestTypeShake256 = "shake256"
+)
+
+var errNoBufConfig = errors.New("neither buf.work.yaml nor buf.yaml found")
+
+type BufWorkspace struct {
+	RootPath           string   `json:"rootPath"`
+	ModuleRootList     []string `json:"moduleRootList"`
+	DependencyPathList []string `json:"dependencyPathList"`
+
+	moduleRoots []*bufModuleRoot
+}
+
+type bufWorkConfig struct {
+	Directories []string `yaml:"directories"`
+}
+
+type bufModuleConfig struct {
+	Version string `yaml:"version"`
+	Build   struct {
+		Roots    []string `yaml:"roots"`
+		Excludes []string `yaml:"excludes"`
+	} `yaml:"build"`
+	Modules []*bufModuleConfigModule `yaml:"modules"`
+}
+
+type bufModuleConfigModule struct {
+	Path     string   `yaml:"path"`
+	Excludes []string `yaml:"excludes"`
+}
+
+// bufModuleRoot is a directory to compile every proto file of, except for the excluded directories.
+type bufModuleRoot struct {
+	path         string
+	excludePaths []string
+}
+
+type bufLockConfig struct {
+	Deps []struct {
+		Remote     string `yaml:"remote"`
+		Owner      string `yaml:"owner"`
+		Repository string `yaml:"repository"`
+		Name       string `yaml:"name"`
+		Commit     string `yaml:"commit"`
+		Digest     string `yaml:"digest"`
+	} `yaml:"deps"`
+}
+
+func NewBufWorkspace(rootPath string) (*BufWorkspace, error) {
+	workspace := &BufWorkspace{RootPath: rootPath}
+
+	moduleRoots, err := bufModuleRoots(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	moduleRootList := lo.Map(moduleRoots, func(moduleRoot *bufModuleRoot, _ int) string {
+		return moduleRoot.path
+	})
+
+	workspace.ModuleRootList = moduleRootList
+	workspace.moduleRoots = moduleRoots
+
+	lockDirList := append([]string{rootPath}, moduleRootList...)
+
+	for _, lockDir := range lockDirList {
+		dependencyPathList, err := bufDependencyPaths(lockDir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dependencyPath := range dependencyPathList {
+			if !lo.Contains(workspace.DependencyPathList, dependencyPath) {
+				workspace.DependencyPathList = append(workspace.DependencyPathList, dependencyPath)
+			}
+		}
+	}
+
+	return workspace, nil
+}
+
+func (w *BufWorkspace) ImportPathList() []string {
+	return append(append([]string{}, w.ModuleRootList...), w.DependencyPathList...)
+}
+
+func (w *BufWorkspace) ProtoFileList() ([]string, error) {
+	var protoFileList []string
+
+	for _, moduleRoot := range w.moduleRoots {
+		err := filepath.WalkDir(moduleRoot.path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() {
+				if filePath != moduleRoot.path && moduleRoot.isSkipped(filePath, entry) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if filepath.Ext(filePath) != ".proto" {
+				return nil
+			}
+
+			relativePath, err := filepath.Rel(moduleRoot.path, filePath)
+			if err != nil {
+				return err
+			}
+
+			relativePath = filepath.ToSlash(relativePath)
+
+			if !lo.Contains(protoFileList, relativePath) {
+				protoFileList = append(protoFileList, relativePath)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk buf module %s: %w", moduleRoot.path, err)
+		}
+	}
+
+	sort.Strings(protoFileList)
+
+	return protoFileList, nil
+}
+
+func bufModuleRoots(rootPath string) ([]*bufModuleRoot, error) {
+	workConfig := &bufWorkConfig{}
+
+	isFound, err := readBufConfig(filepath.Join(rootPath, bufWorkFileName), workConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if isFound {
+		var moduleRoots []*bufModuleRoot
+
+		for _, directory := range workConfig.Directories {
+			directoryRoots, err := bufConfigModuleRoots(filepath.Join(rootPath, directory), false)
+			if err != nil {
+				return nil, err
+			}
+
+			moduleRoots = append(moduleRoots, directoryRoots...)
+		}
+
+		return moduleRoots, nil
+	}
+
+	return bufConfigModuleRoots(rootPath, true)
+}
+
+// bufConfigModuleRoots reads the module roots of a buf.yaml, a directory of a buf.work.yaml may go without one.
+func bufConfigModuleRoots(configPath string, isRequired bool) ([]*bufModuleRoot, error) {
+	moduleConfig := &bufModuleConfig{}
+
+	isFound, err := readBufConfig(filepath.Join(configPath, bufModuleFileName), moduleConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isFound && isRequired {
+		return nil, fmt.Errorf("%w in %s", errNoBufConfig, configPath)
+	}
+
+	if len(moduleConfig.Modules) > 0 {
+		return lo.Map(moduleConfig.Modules, func(module *bufModuleConfigModule, _ int) *bufModuleRoot {
+			return &bufModuleRoot{
+				path:         filepath.Join(configPath, module.Path),
+				excludePaths: bufJoinPaths(configPath, module.Excludes),
+			}
+		}), nil
+	}
+
+	relativeRootList := moduleConfig.Build.Roots
+	if len(relativeRootList) == 0 {
+		relativeRootList = []string{"."}
+	}
+
+	excludePaths := bufJoinPaths(configPath, moduleConfig.Build.Excludes)
+
+	return lo.Map(relativeRootList, func(relativeRoot string, _ int) *bufModuleRoot {
+		return &bufModuleRoot{
+			path:         filepath.Join(configPath, relativeRoot),
+			excludePaths: excludePaths,
+		}
+	}), nil
+}
+
+// isSkipped tells whether a directory is left out of the module: hidden and excluded directories are,
+// as well as the nested ones with a buf config of their own, since those are separate modules.
+func (r *bufModuleRoot) isSkipped(directoryPath string, entry fs.DirEntry) bool {
+	if strings.HasPrefix(entry.Name(), ".") || lo.Contains(r.excludePaths, directoryPath) {
+		return true
+	}
+
+	for _, configFileName := range []string{bufModuleFileName, bufWorkFileName} {
+		_, err := os.Stat(filepath.Join(directoryPath, configFileName))
+		if err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bufJoinPaths(basePath string, relativePathList []string) []string {
+	return lo.Map(relativePathList, func(relativePath string, _ int) string {
+		return filepath.Join(basePath, relativePath)
+	})
+}
+
+func bufDependencyPaths(moduleRoot string) ([]string, error) {
+	lockConfig := &bufLockConfig{}
+
+	isFound, err := readBufConfig(filepath.Join(moduleRoot, bufLockFileName), lockConfig)
+	if err != nil || !isFound {
+		return nil, err
+	}
+
+	cacheDir := bufCacheDir()
+
+	var dependencyPathList []string
+
+	for _, dep := range lockConfig.Deps {
+		remote, owner, repository := dep.Remote, dep.Owner, dep.Repository
+
+		if dep.Name != "" {
+			nameParts := strings.SplitN(dep.Name, "/", 3) // nolint: gomnd
+			if len(nameParts) == 3 {                      // nolint: gomnd
+				remote, owner, repository = nameParts[0], nameParts[1], nameParts[2]
+			}
+		}
+
+		if remote == "" {
+			remote = bufDefaultRemote
+		}
+
+		cachePathList := bufModuleCachePaths(cacheDir, remote, owner, repository, dep.Commit, dep.Digest)
+
+		for _, candidate := range cachePathList {
+			info, err := os.Stat(candidate)
+			if err == nil && info.IsDir() {
+				dependencyPathList = append(dependencyPathList, candidate)
+
+				break
+			}
+		}
+	}
+
+	return dependencyPathList, nil
+}
+
+// bufModuleCachePaths returns where buf keeps the files of a module commit: releases before v1.32 store them
+// under v1/module/data, the later ones under v3/modules keyed by the digest type of the buf.lock entry,
+// where the shake256 digests of v1 lock files are stored as b4.
+func bufModuleCachePaths(cacheDir, remote, owner, repository, commit, digest string) []string {
+	cachePathList := []string{
+		filepath.Join(cacheDir, "v1", "module", "data", remote, owner, repository, commit),
+	}
+
+	digestTypeList := []string{bufDigestTypeB5, bufDigestTypeB4}
+
+	if digestType, _, isFound := strings.Cut(digest, ":"); isFound {
+		if digestType == bufDigestTypeShake256 {
+			digestType = bufDigestTypeB4
+		}
+
+		digestTypeList = []string{digestType}
+	}
+
+	for _, digestType := range digestTypeList {
+		modulePath := filepath.Join(cacheDir, "v3", "modules", digestType, remote, owner, repository, commit)
+		cachePathList = append(cachePathList, filepath.Join(modulePath, "files"))
+	}
+
+	return cachePathList
+}
+
+func bufCacheDir() string {
+	if cacheDir := os.Getenv("BUF_CACHE_DIR"); cacheDir != "" {
+		return cacheDir
+	}
+
+	return filepath.Join(xdg.CacheHome, "buf")
+}
+
+func readBufConfig(filePath string, destination any) (bool, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	err = yaml.Unmarshal(data, destination)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
+
+	return true, nil
+}
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -24,6 +25,8 @@ import (
 
 const requestTimeout = time.Second * 5
 
+var errDynamicMessage = errors.New("expected dynamic message")
+
 type ResponseJSON struct {
 	Error *ResponseJSONError `json:"error"`
 }
@@ -31,7 +34,7 @@ type ResponseJSON struct {
 type ResponseJSONError struct {
 	Code    string                   `json:"code"`
 	Message string                   `json:"message"`
-	Details []map[string]interface{} `json:"details,omitempty"`
+	Details []map[string]interface{} `json:"details,omitempty" ts_type:"Array<Record<string, any>>"`
 }
 
 type Form struct {
@@ -43,13 +46,15 @@ type Form struct {
 	Response         string    `json:"response"`
 
 	connection        *grpc.ClientConn
+	connectionAddress string
 	requestCancelFunc context.CancelFunc
 }
 
+// SendRequest sends every payload as a request frame, more than one fits client and bidi streaming methods only.
 func (f *Form) SendRequest(
 	methodID,
-	address,
-	payload string,
+	address string,
+	payloads []string,
 	protoDescriptorSource grpcurl.DescriptorSource,
 	headers []*Header,
 ) (string, error) {
@@ -76,12 +81,18 @@ func (f *Form) SendRequest(
 		grpcHeaders,
 		responseHandler,
 		func(message proto.Message) error {
-			err := jsonpb.UnmarshalString(payload, message)
+			if len(payloads) == 0 {
+				return io.EOF
+			}
+
+			err := jsonpb.UnmarshalString(payloads[0], message)
 			if err != nil {
 				return fmt.Errorf("failed to unmarshal grpc request: %w", err)
 			}
 
-			return io.EOF
+			payloads = payloads[1:]
+
+			return nil
 		},
 	)
 	if err != nil {
@@ -131,7 +142,8 @@ func (f *Form) Close() error {
 }
 
 func (f *Form) establishConnection(ctx context.Context, address string) error {
-	if address == f.Address && f.connection != nil {
+	// the address of the form may be updated before the call, so the connection keeps its own
+	if address == f.connectionAddress && f.connection != nil {
 		return nil
 	}
 
@@ -157,6 +169,7 @@ func (f *Form) establishConnection(ctx context.Context, address string) error {
 	}
 
 	f.connection = connection
+	f.connectionAddress = address
 
 	return nil
 }
@@ -171,9 +184,58 @@ func (h *responseHandler) OnReceiveTrailers(status *status.Status, _ metadata.MD
 		return
 	}
 
+	responseJSON := &ResponseJSON{
+		Error: formatStatus(status, h.protoDescriptorSource),
+	}
+
+	response, err := json.Marshal(responseJSON)
+	if err != nil {
+		h.response = err.Error()
+
+		return
+	}
+
+	h.response = string(response)
+}
+
+func (h *responseHandler) OnResolveMethod(_ *desc.MethodDescriptor) {
+}
+
+func (h *responseHandler) OnSendHeaders(_ metadata.MD) {
+}
+
+func (h *responseHandler) OnReceiveHeaders(_ metadata.MD) {
+}
+
+func (h *responseHandler) OnReceiveResponse(message proto.Message) {
+	responseJSON, err := formatMessage(message)
+	if err != nil {
+		h.response = err.Error()
+
+		return
+	}
+
+	h.response = responseJSON
+}
+
+func formatMessage(message proto.Message) (string, error) {
+	dynamicMessage, ok := message.(*dynamic.Message)
+	if !ok {
+		return "", fmt.Errorf("%w, got %T instead", errDynamicMessage, message)
+	}
+
+	messageJSON, err := dynamicMessage.MarshalJSONPB(&jsonpb.Marshaler{EmitDefaults: true, OrigName: true})
+	if err != nil {
+		return "", fmt.Errorf("cannot parse the response due to an error: %w", err)
+	}
+
+	return string(messageJSON), nil
+}
+
+func formatStatus(status *status.Status, protoDescriptorSource grpcurl.DescriptorSource) *ResponseJSONError {
 	formatter := grpcurl.NewJSONFormatter(
 		true,
-		grpcurl.AnyResolverFromDescriptorSourceWithFallback(h.protoDescriptorSource),
+		grpcurl.AnyResolverFromDescriptorSourceWithFallback(protoDescriptorSource),
 	)
 
 	protoDetails := status.Proto().Details
@@ -205,47 +267,9 @@ func (h *responseHandler) OnReceiveTrailers(status *status.Status, _ metadata.MD
 		details = append(details, detailMapWithoutType)
 	}
 
-	responseJSON := &ResponseJSON{
-		Error: &ResponseJSONError{
-			Code:    status.Code().String(),
-			Message: status.Message(),
-			Details: details,
-		},
-	}
-
-	response, err := json.Marshal(responseJSON)
-	if err != nil {
-		h.response = err.Error()
-
-		return
+	return &ResponseJSONError{
+		Code:    status.Code().String(),
+		Message: status.Message(),
+		Details: details,
 	}
-
-	h.response = string(response)
-}
-
-func (h *responseHandler) OnResolveMethod(_ *desc.MethodDescriptor) {
-}
-
-func (h *responseHandler) OnSendHeaders(_ metadata.MD) {
-}
-
-func (h *responseHandler) OnReceiveHeaders(_ metadata.MD) {
-}
-
-func (h *responseHandler) OnReceiveResponse(message proto.Message) {
-	dynamicMessage, ok := message.(*dynamic.Message)
-	if !ok {
-		h.response = fmt.Sprintf("expected dynamic message, got %T instead", message)
-
-		return
-	}
-
-	responseJSON, err := dynamicMessage.MarshalJSONPB(&jsonpb.Marshaler{EmitDefaults: true, OrigName: true})
-	if err != nil {
-		h.response = fmt.Sprintf("cannot parse the response due to an error: %s", err)
-
-		return
-	}
-
-	h.response = string(responseJSON)
 }
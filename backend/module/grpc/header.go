@@ -5,3 +5,14 @@ type Header struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 }
+
+func copyHeaders(headers []*Header) []*Header {
+	headersCopy := make([]*Header, 0, len(headers))
+
+	for _, header := range headers {
+		headerCopy := *header
+		headersCopy = append(headersCopy, &headerCopy)
+	}
+
+	return headersCopy
+}
@@ -58,6 +58,62 @@ func (m *Module) StopRequest(projectID, formID string) (*Project, error) {
 	return project, nil
 }
 
+func (m *Module) StartProxy(projectID, listenAddress, upstreamAddress string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.StartProxy(m.AppCtx, listenAddress, upstreamAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) StopProxy(projectID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.StopProxy()
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) ClearProxyHistory(projectID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.ClearProxyHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) ReplayProxyEntry(projectID, formID, entryID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.ReplayProxyEntry(formID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
 func (m *Module) ReflectProto(projectID, formID, address string) (*Project, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -150,6 +206,29 @@ func (m *Module) OpenImportPath(projectID string) (*Project, error) {
 	return project, nil
 }
 
+func (m *Module) OpenBufWorkspace(projectID string) (*Project, error) {
+	rootPath, err := runtime.OpenDirectoryDialog(m.AppCtx, runtime.OpenDialogOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open buf workspace: %w", err)
+	}
+
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rootPath == "" {
+		return project, nil
+	}
+
+	err = project.OpenBufWorkspace(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
 func (m *Module) SelectMethod(projectID, formID, methodID string) (*Project, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -368,6 +447,10 @@ func (m *Module) fetchProject(projectID string) (*Project, error) {
 
 	project.stateStorage = m.stateStorage
 
+	if project.Proxy != nil {
+		project.Proxy.IsRunning = false
+	}
+
 	if len(project.ProtoFileList) > 0 {
 		_, err := project.RefreshProtoDescriptors(
 			project.ImportPathList,
@@ -11,7 +11,9 @@ import (
 	"github.com/fullstorydev/grpcurl"
 	"github.com/gofrs/uuid/v5"
 	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
 	"github.com/samber/lo"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 	"google.golang.org/protobuf/types/descriptorpb"
 
@@ -27,7 +29,9 @@ type Project struct {
 	IsReflected    bool             `json:"isReflected"`
 	ImportPathList []string         `json:"importPathList"`
 	ProtoFileList  []string         `json:"protoFileList"`
+	BufWorkspace   *BufWorkspace    `json:"bufWorkspace"`
 	Nodes          []*ProtoTreeNode `json:"nodes"`
+	Proxy          *Proxy           `json:"proxy"`
 
 	stateMutex            sync.RWMutex
 	stateStorage          *state.Storage
@@ -51,6 +55,7 @@ func NewProject(projectID string, stateStorage *state.Storage) (*Project, error)
 			},
 		},
 		CurrentFormID: formID,
+		Proxy:         newProxy(),
 		stateStorage:  stateStorage,
 	}
 	project.FormIDs = append(project.FormIDs, formID)
@@ -74,8 +79,109 @@ func (p *Project) SendRequest(
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
 
+	return p.sendRequest(formID, address, []string{payload})
+}
+
+func (p *Project) StopRequest(id string) {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	form := p.Forms[id]
+
+	form.StopCurrentRequest()
+}
+
+func (p *Project) StartProxy(ctx context.Context, listenAddress, upstreamAddress string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if p.Proxy == nil {
+		p.Proxy = newProxy()
+	}
+
+	if p.IsReflected && !p.IsProtoDescriptorSourceInitialized() {
+		_, err := p.reflectProto(p.CurrentFormID, upstreamAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.Proxy.ListenAddress = listenAddress
+	p.Proxy.UpstreamAddress = upstreamAddress
+
+	err := p.Proxy.Start(p.protoDescriptorSource, func(entry *ProxyHistoryEntry) {
+		go p.recordProxyEntry(ctx, entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.saveState()
+}
+
+func (p *Project) StopProxy() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if p.Proxy == nil {
+		return errProxyNotRunning
+	}
+
+	err := p.Proxy.Stop()
+	if err != nil {
+		return err
+	}
+
+	return p.saveState()
+}
+
+func (p *Project) ClearProxyHistory() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if p.Proxy == nil {
+		return nil
+	}
+
+	p.Proxy.History = nil
+
+	return p.saveState()
+}
+
+func (p *Project) ReplayProxyEntry(formID, entryID string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if p.Proxy == nil {
+		return errProxyEntryNotFound
+	}
+
+	entry, err := p.Proxy.Entry(entryID)
+	if err != nil {
+		return err
+	}
+
+	if entry.HasUndecodedRequests {
+		return errProxyEntryNotDecoded
+	}
+
+	form := p.Forms[formID]
+	form.SelectedMethodID = entry.MethodID
+	form.Headers = copyHeaders(entry.RequestHeaders)
+
+	// entries recorded before the upstream was kept are replayed against the current one
+	address := entry.UpstreamAddress
+	if address == "" {
+		address = p.Proxy.UpstreamAddress
+	}
+
+	return p.sendRequest(formID, address, entry.Requests)
+}
+
+// sendRequest keeps the first payload as the form request, the rest are the frames of a replayed stream.
+func (p *Project) sendRequest(formID, address string, payloads []string) error {
 	p.Forms[formID].Address = address
-	p.Forms[formID].Request = payload
+	p.Forms[formID].Request = payloads[0]
 
 	if p.IsReflected && !p.IsProtoDescriptorSourceInitialized() {
 		_, err := p.reflectProto(formID, address)
@@ -89,7 +195,7 @@ func (p *Project) SendRequest(
 	response, err := form.SendRequest(
 		p.Forms[formID].SelectedMethodID,
 		address,
-		payload,
+		payloads,
 		p.protoDescriptorSource,
 		p.Forms[formID].Headers,
 	)
@@ -104,15 +210,6 @@ func (p *Project) SendRequest(
 	return p.saveState()
 }
 
-func (p *Project) StopRequest(id string) {
-	p.stateMutex.Lock()
-	defer p.stateMutex.Unlock()
-
-	form := p.Forms[id]
-
-	form.StopCurrentRequest()
-}
-
 func (p *Project) ReflectProto(formID, address string) error {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
@@ -131,6 +228,7 @@ func (p *Project) ReflectProto(formID, address string) error {
 	p.Nodes = nodes
 	p.ImportPathList = nil
 	p.ProtoFileList = nil
+	p.BufWorkspace = nil
 
 	return p.saveState()
 }
@@ -180,12 +278,47 @@ func (p *Project) OpenProtoFile(protoFilePath string) error {
 	return p.saveState()
 }
 
+func (p *Project) OpenBufWorkspace(rootPath string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	bufWorkspace, err := NewBufWorkspace(rootPath)
+	if err != nil {
+		return err
+	}
+
+	protoFileList, err := bufWorkspace.ProtoFileList()
+	if err != nil {
+		return err
+	}
+
+	importPathList := bufWorkspace.ImportPathList()
+
+	previousBufWorkspace := p.BufWorkspace
+	p.BufWorkspace = bufWorkspace
+
+	nodes, err := p.RefreshProtoDescriptors(importPathList, protoFileList)
+	if err != nil {
+		p.BufWorkspace = previousBufWorkspace
+
+		return err
+	}
+
+	p.IsReflected = false
+	p.Nodes = nodes
+	p.ImportPathList = importPathList
+	p.ProtoFileList = protoFileList
+
+	return p.saveState()
+}
+
 func (p *Project) DeleteAllProtoFiles() error {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
 
 	p.IsReflected = false
 	p.ProtoFileList = nil
+	p.BufWorkspace = nil
 
 	nodes, err := p.RefreshProtoDescriptors(
 		p.ImportPathList,
@@ -408,11 +541,20 @@ func (p *Project) RemoveForm(formID string) error {
 	return p.saveState()
 }
 
+// RefreshProtoDescriptors compiles buf workspaces with their own resolver, since a module there may import
+// files that are opened as well, while other projects are compiled by grpcurl as they always were.
 func (p *Project) RefreshProtoDescriptors(importPathList, protoFileList []string) ([]*ProtoTreeNode, error) {
-	protoDescriptorSource, err := grpcurl.DescriptorSourceFromProtoFiles(
-		importPathList,
-		protoFileList...,
+	var (
+		protoDescriptorSource grpcurl.DescriptorSource
+		err                   error
 	)
+
+	if p.BufWorkspace != nil {
+		protoDescriptorSource, err = descriptorSourceFromProtoFiles(importPathList, protoFileList)
+	} else {
+		protoDescriptorSource, err = grpcurl.DescriptorSourceFromProtoFiles(importPathList, protoFileList...)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to read from proto files: %w", err)
 	}
@@ -421,6 +563,12 @@ func (p *Project) RefreshProtoDescriptors(importPathList, protoFileList []string
 }
 
 func (p *Project) Close() error {
+	if p.Proxy != nil {
+		if err := p.Proxy.Close(); err != nil {
+			return err
+		}
+	}
+
 	for _, form := range p.Forms {
 		err := form.Close()
 		if err != nil {
@@ -440,6 +588,10 @@ func (p *Project) refreshProtoNodes(protoDescriptorSource grpcurl.DescriptorSour
 	p.protoDescriptorSource = protoDescriptorSource
 	p.protoTree = protoTree
 
+	if p.Proxy != nil {
+		p.Proxy.setProtoDescriptorSource(protoDescriptorSource)
+	}
+
 	return protoTree.Nodes(), nil
 }
 
@@ -459,6 +611,17 @@ func (p *Project) reflectProto(formID, address string) ([]*ProtoTreeNode, error)
 	return nodes, nil
 }
 
+func (p *Project) recordProxyEntry(ctx context.Context, entry *ProxyHistoryEntry) {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	p.Proxy.Record(entry)
+
+	runtime.EventsEmit(ctx, fmt.Sprintf("grpc_proxy_%s", p.ID), entry)
+
+	_ = p.saveState()
+}
+
 func (p *Project) saveState() error {
 	err := p.stateStorage.Save(p.ID, p)
 	if err != nil {
@@ -468,6 +631,61 @@ func (p *Project) saveState() error {
 	return nil
 }
 
+// descriptorSourceFromProtoFiles mirrors grpcurl.DescriptorSourceFromProtoFiles, but skips the files
+// that are already imported by other files, as the parser returns distinct descriptors for them and
+// grpcurl rejects such duplicates.
+// nolint: ireturn
+func descriptorSourceFromProtoFiles(
+	importPathList,
+	protoFileList []string,
+) (grpcurl.DescriptorSource, error) {
+	resolvedFileList, err := protoparse.ResolveFilenames(importPathList, protoFileList...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve proto file names: %w", err)
+	}
+
+	parser := protoparse.Parser{
+		ImportPaths:           importPathList,
+		InferImportPaths:      len(importPathList) == 0,
+		IncludeSourceCodeInfo: true,
+	}
+
+	fileDescriptors, err := parser.ParseFiles(resolvedFileList...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto files: %w", err)
+	}
+
+	importedFiles := map[string]bool{}
+
+	var collectImports func(fileDescriptor *desc.FileDescriptor)
+	collectImports = func(fileDescriptor *desc.FileDescriptor) {
+		for _, dependency := range fileDescriptor.GetDependencies() {
+			if importedFiles[dependency.GetName()] {
+				continue
+			}
+
+			importedFiles[dependency.GetName()] = true
+
+			collectImports(dependency)
+		}
+	}
+
+	for _, fileDescriptor := range fileDescriptors {
+		collectImports(fileDescriptor)
+	}
+
+	rootFileDescriptors := lo.Reject(fileDescriptors, func(fileDescriptor *desc.FileDescriptor, _ int) bool {
+		return importedFiles[fileDescriptor.GetName()]
+	})
+
+	protoDescriptorSource, err := grpcurl.DescriptorSourceFromFileDescriptors(rootFileDescriptors...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build a descriptor source: %w", err)
+	}
+
+	return protoDescriptorSource, nil
+}
+
 func parseProtoField(field *desc.FieldDescriptor) interface{} {
 	if field.IsRepeated() {
 		v := parseProtoType(field)
@@ -0,0 +1,425 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fullstorydev/grpcurl"
+	"github.com/gofrs/uuid/v5"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	proxyHistoryLimit           = 100
+	defaultProxyListenAddress   = "127.0.0.1:50052"
+	defaultProxyUpstreamAddress = "0.0.0.0:50051"
+)
+
+var (
+	errRawFrame              = errors.New("expected raw frame")
+	errProxyAlreadyRunning   = errors.New("proxy is already running")
+	errProxyNotRunning       = errors.New("proxy is not running")
+	errProxyMethodNotFound   = errors.New("method not found in server stream")
+	errProxyEntryNotFound    = errors.New("proxy history entry not found")
+	errProxyEntryHasNoFrames = errors.New("proxy history entry has no requests")
+	errProxyEntryNotDecoded  = errors.New("proxy history entry has requests that could not be decoded")
+)
+
+type Proxy struct {
+	ListenAddress   string               `json:"listenAddress"`
+	UpstreamAddress string               `json:"upstreamAddress"`
+	IsRunning       bool                 `json:"isRunning"`
+	History         []*ProxyHistoryEntry `json:"history"`
+
+	server     *grpc.Server
+	connection *grpc.ClientConn
+	onEntry    func(entry *ProxyHistoryEntry)
+
+	// the descriptor source is replaced whenever the project reloads its protos while the proxy is running
+	protoDescriptorSource      grpcurl.DescriptorSource
+	protoDescriptorSourceMutex sync.RWMutex
+}
+
+type ProxyHistoryEntry struct {
+	ID               string              `json:"id"`
+	MethodID         string              `json:"methodID"`
+	UpstreamAddress  string              `json:"upstreamAddress"`
+	StartedAt        string              `json:"startedAt"`
+	DurationMs       int64               `json:"durationMs"`
+	RequestHeaders   []*Header           `json:"requestHeaders"`
+	Requests         []string            `json:"requests"`
+	ResponseHeaders  map[string][]string `json:"responseHeaders"`
+	ResponseTrailers map[string][]string `json:"responseTrailers"`
+	Responses        []string            `json:"responses"`
+	Status           *ResponseJSONError  `json:"status"`
+	// HasUndecodedRequests tells that some requests are kept as base64 frames, which cannot be replayed.
+	HasUndecodedRequests bool `json:"hasUndecodedRequests"`
+
+	mutex    sync.Mutex
+	isClosed bool
+}
+
+func newProxy() *Proxy {
+	return &Proxy{
+		ListenAddress:   defaultProxyListenAddress,
+		UpstreamAddress: defaultProxyUpstreamAddress,
+	}
+}
+
+func (p *Proxy) Start(
+	protoDescriptorSource grpcurl.DescriptorSource,
+	onEntry func(entry *ProxyHistoryEntry),
+) error {
+	if p.server != nil {
+		return errProxyAlreadyRunning
+	}
+
+	connection, err := grpc.Dial(
+		p.UpstreamAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to dial upstream: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", p.ListenAddress)
+	if err != nil {
+		return errors.Join(
+			fmt.Errorf("failed to listen on %s: %w", p.ListenAddress, err),
+			connection.Close(),
+		)
+	}
+
+	p.connection = connection
+	p.onEntry = onEntry
+	p.setProtoDescriptorSource(protoDescriptorSource)
+	p.server = grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(p.handleStream),
+	)
+	p.IsRunning = true
+
+	go func(server *grpc.Server) {
+		_ = server.Serve(listener)
+	}(p.server)
+
+	return nil
+}
+
+func (p *Proxy) Stop() error {
+	if p.server == nil {
+		return errProxyNotRunning
+	}
+
+	p.server.Stop()
+	p.server = nil
+	p.IsRunning = false
+
+	err := p.connection.Close()
+	p.connection = nil
+
+	if err != nil {
+		return fmt.Errorf("failed to close upstream connection: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Proxy) Close() error {
+	if p.server == nil {
+		return nil
+	}
+
+	return p.Stop()
+}
+
+func (p *Proxy) setProtoDescriptorSource(protoDescriptorSource grpcurl.DescriptorSource) {
+	p.protoDescriptorSourceMutex.Lock()
+	defer p.protoDescriptorSourceMutex.Unlock()
+
+	p.protoDescriptorSource = protoDescriptorSource
+}
+
+func (p *Proxy) descriptorSource() grpcurl.DescriptorSource {
+	p.protoDescriptorSourceMutex.RLock()
+	defer p.protoDescriptorSourceMutex.RUnlock()
+
+	return p.protoDescriptorSource
+}
+
+func (p *Proxy) Record(entry *ProxyHistoryEntry) {
+	p.History = append([]*ProxyHistoryEntry{entry}, p.History...)
+
+	if len(p.History) > proxyHistoryLimit {
+		p.History = p.History[:proxyHistoryLimit]
+	}
+}
+
+func (p *Proxy) Entry(entryID string) (*ProxyHistoryEntry, error) {
+	for _, entry := range p.History {
+		if entry.ID == entryID {
+			if len(entry.Requests) == 0 {
+				return nil, errProxyEntryHasNoFrames
+			}
+
+			return entry, nil
+		}
+	}
+
+	return nil, errProxyEntryNotFound
+}
+
+// nolint: funlen, cyclop
+func (p *Proxy) handleStream(_ interface{}, serverStream grpc.ServerStream) error {
+	fullMethod, ok := grpc.MethodFromServerStream(serverStream)
+	if !ok {
+		return status.Error(codes.Internal, errProxyMethodNotFound.Error())
+	}
+
+	startedAt := time.Now()
+	methodID := strings.ReplaceAll(strings.TrimPrefix(fullMethod, "/"), "/", ".")
+	protoDescriptorSource := p.descriptorSource()
+	methodDescriptor := findMethod(protoDescriptorSource, methodID)
+
+	requestMetadata, _ := metadata.FromIncomingContext(serverStream.Context())
+	upstreamMetadata := requestMetadata.Copy()
+	delete(upstreamMetadata, ":authority")
+
+	entry := &ProxyHistoryEntry{
+		ID:              uuid.Must(uuid.NewV4()).String(),
+		MethodID:        methodID,
+		UpstreamAddress: p.connection.Target(),
+		StartedAt:       startedAt.Format(time.RFC3339Nano),
+		RequestHeaders:  metadataToHeaders(requestMetadata),
+	}
+
+	defer func() {
+		entry.close()
+		entry.DurationMs = time.Since(startedAt).Milliseconds()
+
+		if p.onEntry != nil {
+			p.onEntry(entry)
+		}
+	}()
+
+	ctx, cancelFunc := context.WithCancel(serverStream.Context())
+	defer cancelFunc()
+
+	clientStream, err := grpc.NewClientStream(
+		metadata.NewOutgoingContext(ctx, upstreamMetadata),
+		&grpc.StreamDesc{ServerStreams: true, ClientStreams: true},
+		p.connection,
+		fullMethod,
+	)
+	if err != nil {
+		entry.Status = formatStatus(status.Convert(err), protoDescriptorSource)
+
+		return err // nolint: wrapcheck
+	}
+
+	go func() {
+		for {
+			frame := &rawFrame{}
+
+			err := serverStream.RecvMsg(frame)
+			if errors.Is(err, io.EOF) {
+				_ = clientStream.CloseSend()
+
+				return
+			}
+
+			if err != nil {
+				cancelFunc()
+
+				return
+			}
+
+			request, isDecoded := decodeFrame(methodDescriptor, true, frame.data)
+			entry.addRequest(request, isDecoded)
+
+			if err := clientStream.SendMsg(frame); err != nil {
+				return
+			}
+		}
+	}()
+
+	isHeaderSent := false
+
+	for {
+		frame := &rawFrame{}
+
+		err := clientStream.RecvMsg(frame)
+
+		if !isHeaderSent {
+			isHeaderSent = true
+
+			if responseMetadata, err := clientStream.Header(); err == nil {
+				entry.ResponseHeaders = responseMetadata
+
+				_ = serverStream.SendHeader(responseMetadata)
+			}
+		}
+
+		if err != nil {
+			entry.ResponseTrailers = clientStream.Trailer()
+			serverStream.SetTrailer(clientStream.Trailer())
+
+			if errors.Is(err, io.EOF) {
+				entry.Status = formatStatus(status.New(codes.OK, ""), protoDescriptorSource)
+
+				return nil
+			}
+
+			entry.Status = formatStatus(status.Convert(err), protoDescriptorSource)
+
+			return err // nolint: wrapcheck
+		}
+
+		response, _ := decodeFrame(methodDescriptor, false, frame.data)
+		entry.addResponse(response)
+
+		if err := serverStream.SendMsg(frame); err != nil {
+			return err // nolint: wrapcheck
+		}
+	}
+}
+
+func findMethod(protoDescriptorSource grpcurl.DescriptorSource, methodID string) *desc.MethodDescriptor {
+	if protoDescriptorSource == nil {
+		return nil
+	}
+
+	descriptor, err := protoDescriptorSource.FindSymbol(methodID)
+	if err != nil {
+		return nil
+	}
+
+	methodDescriptor, _ := descriptor.(*desc.MethodDescriptor)
+
+	return methodDescriptor
+}
+
+func (e *ProxyHistoryEntry) close() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.isClosed = true
+}
+
+func (e *ProxyHistoryEntry) addRequest(request string, isDecoded bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.isClosed {
+		return
+	}
+
+	e.Requests = append(e.Requests, request)
+
+	if !isDecoded {
+		e.HasUndecodedRequests = true
+	}
+}
+
+func (e *ProxyHistoryEntry) addResponse(response string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.isClosed {
+		return
+	}
+
+	e.Responses = append(e.Responses, response)
+}
+
+// decodeFrame falls back to base64 for the frames it cannot decode, which is reported as not decoded.
+func decodeFrame(methodDescriptor *desc.MethodDescriptor, isRequest bool, data []byte) (string, bool) {
+	if methodDescriptor == nil {
+		return base64.StdEncoding.EncodeToString(data), false
+	}
+
+	messageDescriptor := methodDescriptor.GetOutputType()
+	if isRequest {
+		messageDescriptor = methodDescriptor.GetInputType()
+	}
+
+	message := dynamic.NewMessage(messageDescriptor)
+
+	if err := message.Unmarshal(data); err != nil {
+		return base64.StdEncoding.EncodeToString(data), false
+	}
+
+	messageJSON, err := formatMessage(message)
+	if err != nil {
+		return base64.StdEncoding.EncodeToString(data), false
+	}
+
+	return messageJSON, true
+}
+
+func metadataToHeaders(md metadata.MD) []*Header {
+	headers := make([]*Header, 0, len(md))
+
+	for key, values := range md {
+		if key == "content-type" ||
+			key == "user-agent" ||
+			strings.HasPrefix(key, ":") ||
+			strings.HasPrefix(key, "grpc-") {
+			continue
+		}
+
+		for _, value := range values {
+			headers = append(headers, &Header{
+				ID:    uuid.Must(uuid.NewV4()).String(),
+				Key:   key,
+				Value: value,
+			})
+		}
+	}
+
+	return headers
+}
+
+type rawFrame struct {
+	data []byte
+}
+
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	frame, ok := v.(*rawFrame)
+	if !ok {
+		return nil, fmt.Errorf("%w, got %T instead", errRawFrame, v)
+	}
+
+	return frame.data, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	frame, ok := v.(*rawFrame)
+	if !ok {
+		return fmt.Errorf("%w, got %T instead", errRawFrame, v)
+	}
+
+	frame.data = append([]byte(nil), data...)
+
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/catake-com/multibase/backend/state"
+)
+
+func TestProxyRecordsAndReplaysEntries(t *testing.T) {
+	upstreamAddress := startTestUpstream(t)
+	project := newTestProject(t)
+	formID := project.CurrentFormID
+
+	entries := make(chan *ProxyHistoryEntry, 1)
+
+	// the proxy starts before any proto is loaded, the descriptors reflected later still decode its frames
+	project.Proxy.ListenAddress = freeTestAddress(t)
+	project.Proxy.UpstreamAddress = upstreamAddress
+
+	err := project.Proxy.Start(nil, func(entry *ProxyHistoryEntry) {
+		entries <- entry
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = project.Close()
+	})
+
+	err = project.ReflectProto(formID, upstreamAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connection, err := grpc.Dial(
+		project.Proxy.ListenAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer connection.Close()
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFunc()
+
+	// nolint: nosnakecase
+	_, err = grpc_health_v1.NewHealthClient(connection).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entry *ProxyHistoryEntry
+
+	select {
+	case entry = <-entries:
+	case <-ctx.Done():
+		t.Fatal("expected the call to be recorded")
+	}
+
+	if entry.MethodID != "grpc.health.v1.Health.Check" || entry.UpstreamAddress != upstreamAddress {
+		t.Fatalf("unexpected entry: %s %s", entry.MethodID, entry.UpstreamAddress)
+	}
+
+	if entry.HasUndecodedRequests {
+		t.Fatalf("expected the requests to be decoded, got %v", entry.Requests)
+	}
+
+	project.Proxy.Record(entry)
+
+	// the form points elsewhere, the entry is replayed against the upstream it was recorded from
+	err = project.Forms[formID].establishConnection(context.Background(), "127.0.0.1:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = project.ReplayProxyEntry(formID, entry.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := project.Forms[formID]
+
+	if form.Address != upstreamAddress || !strings.Contains(form.Response, "SERVING") {
+		t.Fatalf("unexpected replay of %s: %s", form.Address, form.Response)
+	}
+}
+
+func startTestUpstream(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer()) // nolint: nosnakecase
+	reflection.Register(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func freeTestAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func newTestProject(t *testing.T) *Project {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+
+	stateStorage, err := state.NewStorage(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = stateStorage.Close()
+	})
+
+	project, err := NewProject("test", stateStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return project
+}
@@ -0,0 +1,181 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+var errBrokerNotFound = errors.New("broker not found")
+
+func (p *Project) Overview() (*TabOverviewData, error) {
+	tabOverviewData := &TabOverviewData{
+		IsConnected: p.state.IsConnected,
+	}
+
+	if !tabOverviewData.IsConnected {
+		return tabOverviewData, nil
+	}
+
+	ctx := context.Background()
+
+	metadata, err := p.client.Metadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cluster metadata: %w", err)
+	}
+
+	tabOverviewData.ClusterID = metadata.Cluster
+	tabOverviewData.ControllerID = int(metadata.Controller)
+	tabOverviewData.BrokerCount = len(metadata.Brokers)
+	tabOverviewData.TopicCount = len(metadata.Topics)
+
+	for _, kafkaTopic := range metadata.Topics {
+		for _, partition := range kafkaTopic.Partitions {
+			tabOverviewData.PartitionCount++
+
+			if partition.Leader < 0 {
+				tabOverviewData.OfflinePartitionCount++
+			}
+
+			if len(partition.ISR) < len(partition.Replicas) {
+				tabOverviewData.UnderReplicatedPartitionCount++
+			}
+		}
+	}
+
+	// log dirs are not available to every user or on every cluster,
+	// the rest of the overview is still useful without sizes
+	logDirs, err := p.client.DescribeAllLogDirs(ctx, nil)
+	if err != nil {
+		tabOverviewData.Error = fmt.Sprintf("failed to describe log dirs: %s", err)
+
+		return tabOverviewData, nil
+	}
+
+	logDirs.Each(func(logDir kadm.DescribedLogDir) {
+		tabOverviewData.TotalSize += logDir.Size()
+	})
+
+	return tabOverviewData, nil
+}
+
+func (p *Project) BrokerDetails(brokerID int) (*BrokerDetails, error) {
+	if !p.state.IsConnected {
+		return nil, errNotConnected
+	}
+
+	ctx := context.Background()
+
+	metadata, err := p.client.BrokerMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list brokers: %w", err)
+	}
+
+	brokerDetails := &BrokerDetails{}
+
+	for _, kafkaBroker := range metadata.Brokers {
+		if kafkaBroker.NodeID == int32(brokerID) {
+			brokerDetails.Broker = newTabBrokersDataBroker(kafkaBroker, metadata.Controller)
+		}
+	}
+
+	if brokerDetails.Broker == nil {
+		return nil, fmt.Errorf("%w: %d", errBrokerNotFound, brokerID)
+	}
+
+	resourceConfigs, err := p.client.DescribeBrokerConfigs(ctx, int32(brokerID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe broker configs: %w", err)
+	}
+
+	for _, resourceConfig := range resourceConfigs {
+		if resourceConfig.Err != nil {
+			return nil, fmt.Errorf("failed to describe broker configs: %w", resourceConfig.Err)
+		}
+
+		brokerDetails.Configs = newConfigEntries(resourceConfig.Configs, kmsg.ConfigSourceDynamicBrokerConfig)
+	}
+
+	logDirs, err := p.client.DescribeBrokerLogDirs(ctx, int32(brokerID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe broker log dirs: %w", err)
+	}
+
+	brokerDetails.LogDirs, brokerDetails.TotalSize = newBrokerLogDirs(logDirs)
+
+	apiVersions, err := p.client.ApiVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch broker api versions: %w", err)
+	}
+
+	brokerAPIVersions, ok := apiVersions[int32(brokerID)]
+	if ok && brokerAPIVersions.Err == nil {
+		brokerDetails.KafkaVersion = brokerAPIVersions.VersionGuess()
+
+		brokerAPIVersions.EachKeySorted(func(key, minVersion, maxVersion int16) {
+			brokerDetails.APIVersions = append(brokerDetails.APIVersions, &BrokerAPIVersion{
+				Key:        int(key),
+				Name:       kmsg.NameForKey(key),
+				MinVersion: int(minVersion),
+				MaxVersion: int(maxVersion),
+			})
+		})
+	}
+
+	return brokerDetails, nil
+}
+
+func newTabBrokersDataBroker(kafkaBroker kadm.BrokerDetail, controllerID int32) *TabBrokersDataBroker {
+	broker := &TabBrokersDataBroker{
+		ID:           int(kafkaBroker.NodeID),
+		Host:         kafkaBroker.Host,
+		Port:         int(kafkaBroker.Port),
+		IsController: kafkaBroker.NodeID == controllerID,
+	}
+
+	if kafkaBroker.Rack != nil {
+		broker.Rack = *kafkaBroker.Rack
+	}
+
+	return broker
+}
+
+func newBrokerLogDirs(logDirs kadm.DescribedLogDirs) ([]*BrokerLogDir, int64) {
+	var totalSize int64
+
+	brokerLogDirs := make([]*BrokerLogDir, 0, len(logDirs))
+
+	for _, logDir := range logDirs {
+		brokerLogDir := &BrokerLogDir{
+			Path: logDir.Dir,
+			Size: logDir.Size(),
+		}
+
+		if logDir.Err != nil {
+			brokerLogDir.Error = logDir.Err.Error()
+		}
+
+		for _, partition := range logDir.Topics.Sorted() {
+			brokerLogDir.Partitions = append(brokerLogDir.Partitions, &BrokerLogDirPartition{
+				Topic:       partition.Topic,
+				PartitionID: int(partition.Partition),
+				Size:        partition.Size,
+				OffsetLag:   partition.OffsetLag,
+				IsFuture:    partition.IsFuture,
+			})
+		}
+
+		totalSize += brokerLogDir.Size
+		brokerLogDirs = append(brokerLogDirs, brokerLogDir)
+	}
+
+	sort.Slice(brokerLogDirs, func(i, j int) bool {
+		return brokerLogDirs[i].Path < brokerLogDirs[j].Path
+	})
+
+	return brokerLogDirs, totalSize
+}
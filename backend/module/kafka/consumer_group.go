@@ -0,0 +1,249 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	consumerGroupWatchingDefaultInterval = 5 * time.Second
+	consumerGroupWatchingMinInterval     = time.Second
+)
+
+var (
+	errNotConnected          = errors.New("kafka is not connected")
+	errConsumerGroupNotFound = errors.New("consumer group not found")
+)
+
+func (p *Project) ConsumerGroup(groupName string) (*ConsumerGroup, error) {
+	if !p.state.IsConnected {
+		return nil, errNotConnected
+	}
+
+	ctx := context.Background()
+
+	kafkaGroups, err := p.client.DescribeGroups(ctx, groupName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe consumer group: %w", err)
+	}
+
+	kafkaGroup, ok := kafkaGroups[groupName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errConsumerGroupNotFound, groupName)
+	}
+
+	if kafkaGroup.Err != nil {
+		return nil, fmt.Errorf("failed to describe consumer group: %w", kafkaGroup.Err)
+	}
+
+	commits, err := p.client.FetchOffsets(ctx, groupName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch consumer group offsets: %w", err)
+	}
+
+	topics := kafkaGroup.AssignedPartitions()
+	topics.Merge(commits.Partitions())
+
+	var endOffsets kadm.ListedOffsets
+
+	// listing end offsets without topics lists them for the whole cluster
+	if len(topics) > 0 {
+		endOffsets, err = p.client.ListEndOffsets(ctx, topics.Topics()...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list end offsets: %w", err)
+		}
+	}
+
+	return newConsumerGroup(kafkaGroup, commits, endOffsets), nil
+}
+
+func (p *Project) StartConsumerGroupWatching(ctx context.Context, groupName string, intervalMs int) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if !p.state.IsConnected {
+		return errNotConnected
+	}
+
+	if p.consumerGroupWatchingCancel != nil {
+		p.consumerGroupWatchingCancel()
+	}
+
+	interval := time.Duration(intervalMs) * time.Millisecond
+
+	switch {
+	case intervalMs <= 0:
+		interval = consumerGroupWatchingDefaultInterval
+	case interval < consumerGroupWatchingMinInterval:
+		interval = consumerGroupWatchingMinInterval
+	}
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	p.consumerGroupWatchingCancel = cancelFunc
+
+	eventName := fmt.Sprintf("kafka_consumer_group_%s", p.state.ID)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			consumerGroup, err := p.ConsumerGroup(groupName)
+			if err != nil {
+				p.appLogger.Error(fmt.Errorf("failed to refresh consumer group %s: %w", groupName, err))
+			} else if ctx.Err() == nil {
+				runtime.EventsEmit(ctx, eventName, consumerGroup)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (p *Project) StopConsumerGroupWatching() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if p.consumerGroupWatchingCancel != nil {
+		p.consumerGroupWatchingCancel()
+		p.consumerGroupWatchingCancel = nil
+	}
+
+	return nil
+}
+
+// nolint: funlen
+func newConsumerGroup(
+	kafkaGroup kadm.DescribedGroup,
+	commits kadm.OffsetResponses,
+	endOffsets kadm.ListedOffsets,
+) *ConsumerGroup {
+	consumerGroup := &ConsumerGroup{
+		Name:         kafkaGroup.Group,
+		State:        kafkaGroup.State,
+		ProtocolType: kafkaGroup.ProtocolType,
+		Protocol:     kafkaGroup.Protocol,
+		Coordinator:  newTabBrokersDataBroker(kafkaGroup.Coordinator, -1),
+		Members:      make([]*ConsumerGroupMember, 0, len(kafkaGroup.Members)),
+	}
+
+	for _, kafkaMember := range kafkaGroup.Members {
+		member := &ConsumerGroupMember{
+			MemberID:   kafkaMember.MemberID,
+			ClientID:   kafkaMember.ClientID,
+			ClientHost: kafkaMember.ClientHost,
+		}
+
+		if kafkaMember.InstanceID != nil {
+			member.InstanceID = *kafkaMember.InstanceID
+		}
+
+		if assigned, ok := kafkaMember.Assigned.AsConsumer(); ok {
+			for _, topic := range assigned.Topics {
+				assignment := &ConsumerGroupAssignment{
+					Topic:        topic.Topic,
+					PartitionIDs: make([]int, 0, len(topic.Partitions)),
+				}
+
+				for _, partition := range topic.Partitions {
+					assignment.PartitionIDs = append(assignment.PartitionIDs, int(partition))
+				}
+
+				sort.Ints(assignment.PartitionIDs)
+
+				member.Assignments = append(member.Assignments, assignment)
+			}
+		}
+
+		consumerGroup.Members = append(consumerGroup.Members, member)
+	}
+
+	lag := kadm.CalculateGroupLag(kafkaGroup, commits, endOffsets)
+
+	// committed partitions no member is assigned to anymore still have lag worth showing
+	for topic, partitions := range commits {
+		for partitionID, commit := range partitions {
+			if _, ok := lag.Lookup(topic, partitionID); ok {
+				continue
+			}
+
+			if lag[topic] == nil {
+				lag[topic] = make(map[int32]kadm.GroupMemberLag)
+			}
+
+			memberLag := kadm.GroupMemberLag{Commit: commit.Offset, Lag: -1, Err: commit.Err}
+
+			endOffset, ok := endOffsets.Lookup(topic, partitionID)
+			if ok {
+				memberLag.End = endOffset
+
+				if memberLag.Err == nil {
+					memberLag.Err = endOffset.Err
+				}
+			}
+
+			if ok && memberLag.Err == nil {
+				memberLag.Lag = endOffset.Offset - commit.At
+			}
+
+			lag[topic][partitionID] = memberLag
+		}
+	}
+
+	for topic, partitions := range lag {
+		for partitionID, memberLag := range partitions {
+			partition := &ConsumerGroupPartition{
+				Topic:           topic,
+				PartitionID:     int(partitionID),
+				CommittedOffset: -1,
+				LogEndOffset:    -1,
+				Lag:             memberLag.Lag,
+			}
+
+			if commit, ok := commits.Lookup(topic, partitionID); ok && commit.Err == nil {
+				partition.CommittedOffset = commit.At
+			}
+
+			if endOffset, ok := endOffsets.Lookup(topic, partitionID); ok && endOffset.Err == nil {
+				partition.LogEndOffset = endOffset.Offset
+			}
+
+			if memberLag.Member != nil {
+				partition.MemberID = memberLag.Member.MemberID
+				partition.ClientID = memberLag.Member.ClientID
+			}
+
+			if memberLag.Err != nil {
+				partition.Error = memberLag.Err.Error()
+			}
+
+			if partition.Lag > 0 {
+				consumerGroup.LagTotal += partition.Lag
+			}
+
+			consumerGroup.Partitions = append(consumerGroup.Partitions, partition)
+		}
+	}
+
+	sort.Slice(consumerGroup.Partitions, func(i, j int) bool {
+		if consumerGroup.Partitions[i].Topic != consumerGroup.Partitions[j].Topic {
+			return consumerGroup.Partitions[i].Topic < consumerGroup.Partitions[j].Topic
+		}
+
+		return consumerGroup.Partitions[i].PartitionID < consumerGroup.Partitions[j].PartitionID
+	})
+
+	return consumerGroup
+}
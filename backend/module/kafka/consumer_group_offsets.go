@@ -0,0 +1,521 @@
+package kafka
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+const consumerGroupOperationTTL = 5 * time.Minute
+
+var (
+	errUnknownOffsetResetStrategy       = errors.New("unknown offset reset strategy")
+	errConsumerGroupNotEmpty            = errors.New("consumer group has active members")
+	errInvalidConfirmationToken         = errors.New("invalid or expired confirmation token")
+	errNoCommittedOffset                = errors.New("no committed offset")
+	errNoTimestampOffsetFound           = errors.New("no offset found for the timestamp")
+	errNoConsumerGroupOffsetsToOperate  = errors.New("no consumer group offsets to operate on")
+	errConsumerGroupOperationNotApplied = errors.New("consumer group operation was not fully applied")
+)
+
+// pendingConsumerGroupOperation is a previewed destructive operation waiting for its confirmation token.
+type pendingConsumerGroupOperation struct {
+	plan      *ConsumerGroupOperationPlan
+	expiresAt time.Time
+}
+
+// ResetConsumerGroupOffsets previews a reset when confirmationToken is empty and returns a plan
+// holding a token, passing that token back commits exactly the previewed target offsets.
+func (p *Project) ResetConsumerGroupOffsets(
+	groupName string,
+	reset *ConsumerGroupOffsetsReset,
+	confirmationToken string,
+) (*ConsumerGroupOperationPlan, error) {
+	if confirmationToken != "" {
+		return p.applyConsumerGroupOperation(groupName, ConsumerGroupOperationResetOffsets, confirmationToken)
+	}
+
+	ctx := context.Background()
+
+	err := p.validateConsumerGroupIsEmpty(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := p.offsetsResetPlan(ctx, groupName, reset)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.previewConsumerGroupOperation(plan)
+}
+
+func (p *Project) DeleteConsumerGroupOffsets(
+	groupName,
+	topic,
+	confirmationToken string,
+) (*ConsumerGroupOperationPlan, error) {
+	if confirmationToken != "" {
+		return p.applyConsumerGroupOperation(groupName, ConsumerGroupOperationDeleteOffsets, confirmationToken)
+	}
+
+	ctx := context.Background()
+
+	err := p.validateConsumerGroupIsEmpty(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := p.committedOffsetsPlan(ctx, groupName, ConsumerGroupOperationDeleteOffsets, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.previewConsumerGroupOperation(plan)
+}
+
+func (p *Project) DeleteConsumerGroup(groupName, confirmationToken string) (*ConsumerGroupOperationPlan, error) {
+	if confirmationToken != "" {
+		return p.applyConsumerGroupOperation(groupName, ConsumerGroupOperationDeleteGroup, confirmationToken)
+	}
+
+	ctx := context.Background()
+
+	err := p.validateConsumerGroupIsEmpty(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := p.committedOffsetsPlan(ctx, groupName, ConsumerGroupOperationDeleteGroup, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return p.previewConsumerGroupOperation(plan)
+}
+
+func (p *Project) previewConsumerGroupOperation(plan *ConsumerGroupOperationPlan) (*ConsumerGroupOperationPlan, error) {
+	tokenBytes := make([]byte, 16)
+
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a confirmation token: %w", err)
+	}
+
+	plan.ConfirmationToken = hex.EncodeToString(tokenBytes)
+
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	now := time.Now()
+
+	for token, operation := range p.pendingConsumerGroupOperations {
+		if now.After(operation.expiresAt) {
+			delete(p.pendingConsumerGroupOperations, token)
+		}
+	}
+
+	if p.pendingConsumerGroupOperations == nil {
+		p.pendingConsumerGroupOperations = make(map[string]*pendingConsumerGroupOperation)
+	}
+
+	p.pendingConsumerGroupOperations[plan.ConfirmationToken] = &pendingConsumerGroupOperation{
+		plan:      plan,
+		expiresAt: now.Add(consumerGroupOperationTTL),
+	}
+
+	return plan, nil
+}
+
+// nolint: cyclop
+func (p *Project) applyConsumerGroupOperation(
+	groupName string,
+	operation ConsumerGroupOperation,
+	confirmationToken string,
+) (*ConsumerGroupOperationPlan, error) {
+	p.stateMutex.Lock()
+	pending, ok := p.pendingConsumerGroupOperations[confirmationToken]
+	isValid := ok && time.Now().Before(pending.expiresAt) &&
+		pending.plan.GroupName == groupName && pending.plan.Operation == operation
+
+	// a token is used once, whatever the outcome of the operation
+	if isValid {
+		delete(p.pendingConsumerGroupOperations, confirmationToken)
+	}
+	p.stateMutex.Unlock()
+
+	if !isValid {
+		return nil, errInvalidConfirmationToken
+	}
+
+	plan := pending.plan
+	ctx := context.Background()
+
+	err := p.validateConsumerGroupIsEmpty(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation {
+	case ConsumerGroupOperationResetOffsets:
+		err = p.commitPlanOffsets(ctx, plan)
+	case ConsumerGroupOperationDeleteOffsets:
+		err = p.deletePlanOffsets(ctx, plan)
+	case ConsumerGroupOperationDeleteGroup:
+		var responses kadm.DeleteGroupResponses
+
+		responses, err = p.client.DeleteGroups(ctx, groupName)
+		if err == nil {
+			_, err = responses.On(groupName, func(response *kadm.DeleteGroupResponse) error {
+				return response.Err
+			})
+		}
+
+		if err != nil {
+			err = fmt.Errorf("failed to delete consumer group: %w", err)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	plan.ConfirmationToken = ""
+	plan.IsApplied = true
+
+	return plan, nil
+}
+
+func (p *Project) commitPlanOffsets(ctx context.Context, plan *ConsumerGroupOperationPlan) error {
+	offsets := make(kadm.Offsets)
+
+	for _, partition := range plan.Partitions {
+		if partition.Error != "" {
+			continue
+		}
+
+		offsets.Add(kadm.Offset{
+			Topic:       partition.Topic,
+			Partition:   int32(partition.PartitionID),
+			At:          partition.TargetOffset,
+			LeaderEpoch: -1,
+		})
+	}
+
+	if len(offsets) == 0 {
+		return errNoConsumerGroupOffsetsToOperate
+	}
+
+	responses, err := p.client.CommitOffsets(ctx, plan.GroupName, offsets)
+	if err != nil {
+		return fmt.Errorf("failed to commit consumer group offsets: %w", err)
+	}
+
+	var failedPartition *ConsumerGroupOperationPlanPartition
+
+	for _, partition := range plan.Partitions {
+		response, ok := responses.Lookup(partition.Topic, int32(partition.PartitionID))
+		if ok && response.Err != nil {
+			partition.Error = response.Err.Error()
+
+			if failedPartition == nil {
+				failedPartition = partition
+			}
+		}
+	}
+
+	return failedPartitionError(failedPartition)
+}
+
+func (p *Project) deletePlanOffsets(ctx context.Context, plan *ConsumerGroupOperationPlan) error {
+	topicsSet := make(kadm.TopicsSet)
+
+	for _, partition := range plan.Partitions {
+		topicsSet.Add(partition.Topic, int32(partition.PartitionID))
+	}
+
+	responses, err := p.client.DeleteOffsets(ctx, plan.GroupName, topicsSet)
+	if err != nil {
+		return fmt.Errorf("failed to delete consumer group offsets: %w", err)
+	}
+
+	var failedPartition *ConsumerGroupOperationPlanPartition
+
+	for _, partition := range plan.Partitions {
+		err, ok := responses.Lookup(partition.Topic, int32(partition.PartitionID))
+		if ok && err != nil {
+			partition.Error = err.Error()
+
+			if failedPartition == nil {
+				failedPartition = partition
+			}
+		}
+	}
+
+	return failedPartitionError(failedPartition)
+}
+
+func failedPartitionError(partition *ConsumerGroupOperationPlanPartition) error {
+	if partition == nil {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %s/%d: %s",
+		errConsumerGroupOperationNotApplied,
+		partition.Topic,
+		partition.PartitionID,
+		partition.Error,
+	)
+}
+
+// validateConsumerGroupIsEmpty makes sure no member is running, kafka rejects offset changes of active groups anyway.
+func (p *Project) validateConsumerGroupIsEmpty(ctx context.Context, groupName string) error {
+	if !p.state.IsConnected {
+		return errNotConnected
+	}
+
+	kafkaGroups, err := p.client.DescribeGroups(ctx, groupName)
+	if err != nil {
+		return fmt.Errorf("failed to describe consumer group: %w", err)
+	}
+
+	kafkaGroup, ok := kafkaGroups[groupName]
+	if !ok {
+		return fmt.Errorf("%w: %s", errConsumerGroupNotFound, groupName)
+	}
+
+	if kafkaGroup.Err != nil {
+		return fmt.Errorf("failed to describe consumer group: %w", kafkaGroup.Err)
+	}
+
+	if len(kafkaGroup.Members) > 0 {
+		return fmt.Errorf("%w: %s is %s", errConsumerGroupNotEmpty, groupName, kafkaGroup.State)
+	}
+
+	return nil
+}
+
+// nolint: funlen, cyclop
+func (p *Project) offsetsResetPlan(
+	ctx context.Context,
+	groupName string,
+	reset *ConsumerGroupOffsetsReset,
+) (*ConsumerGroupOperationPlan, error) {
+	if reset == nil {
+		reset = &ConsumerGroupOffsetsReset{}
+	}
+
+	switch reset.Strategy {
+	case OffsetResetStrategyEarliest,
+		OffsetResetStrategyLatest,
+		OffsetResetStrategyOffset,
+		OffsetResetStrategyTimestamp,
+		OffsetResetStrategyShift:
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownOffsetResetStrategy, reset.Strategy)
+	}
+
+	kafkaTopics, err := p.client.ListTopics(ctx, reset.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+
+	kafkaTopic, ok := kafkaTopics[reset.Topic]
+	if !ok || kafkaTopic.Err != nil {
+		return nil, fmt.Errorf("%w: %s", errTopicNotFound, reset.Topic)
+	}
+
+	partitionIDs := kafkaTopic.Partitions.Numbers()
+
+	if len(reset.PartitionIDs) > 0 {
+		partitionIDs = make([]int32, 0, len(reset.PartitionIDs))
+
+		for _, partitionID := range reset.PartitionIDs {
+			if _, ok := kafkaTopic.Partitions[int32(partitionID)]; !ok {
+				return nil, fmt.Errorf("%w: %s/%d", errPartitionNotFound, reset.Topic, partitionID)
+			}
+
+			partitionIDs = append(partitionIDs, int32(partitionID))
+		}
+	}
+
+	var timestampOffsets kadm.ListedOffsets
+
+	if reset.Strategy == OffsetResetStrategyTimestamp {
+		timestamp, err := time.Parse(consumingTimeFromLayout, reset.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse offset reset timestamp: %w", err)
+		}
+
+		timestampOffsets, err = p.client.ListOffsetsAfterMilli(ctx, timestamp.UnixMilli(), reset.Topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list offsets after timestamp: %w", err)
+		}
+	}
+
+	commits, err := p.client.FetchOffsets(ctx, groupName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch consumer group offsets: %w", err)
+	}
+
+	startOffsets, err := p.client.ListStartOffsets(ctx, reset.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list start offsets: %w", err)
+	}
+
+	endOffsets, err := p.client.ListEndOffsets(ctx, reset.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list end offsets: %w", err)
+	}
+
+	plan := &ConsumerGroupOperationPlan{
+		GroupName: groupName,
+		Operation: ConsumerGroupOperationResetOffsets,
+	}
+
+	for _, partitionID := range partitionIDs {
+		partition := newConsumerGroupOperationPlanPartition(
+			reset.Topic,
+			partitionID,
+			commits,
+			startOffsets,
+			endOffsets,
+		)
+
+		switch reset.Strategy {
+		case OffsetResetStrategyEarliest:
+			partition.TargetOffset = partition.LogStartOffset
+		case OffsetResetStrategyLatest:
+			partition.TargetOffset = partition.LogEndOffset
+		case OffsetResetStrategyOffset:
+			partition.TargetOffset = reset.Offset
+		case OffsetResetStrategyTimestamp:
+			timestampOffset, ok := timestampOffsets.Lookup(reset.Topic, partitionID)
+			if !ok || timestampOffset.Err != nil {
+				partition.Error = errNoTimestampOffsetFound.Error()
+			} else {
+				partition.TargetOffset = timestampOffset.Offset
+			}
+		case OffsetResetStrategyShift:
+			if partition.CurrentOffset < 0 {
+				partition.Error = errNoCommittedOffset.Error()
+			} else {
+				partition.TargetOffset = partition.CurrentOffset + reset.Shift
+			}
+		}
+
+		// kafka accepts commits outside of the log, but a consumer would then fall back to its reset policy
+		if partition.Error == "" && partition.TargetOffset < partition.LogStartOffset {
+			partition.TargetOffset = partition.LogStartOffset
+		}
+
+		isAfterLogEnd := partition.LogEndOffset >= 0 && partition.TargetOffset > partition.LogEndOffset
+		if partition.Error == "" && isAfterLogEnd {
+			partition.TargetOffset = partition.LogEndOffset
+		}
+
+		plan.Partitions = append(plan.Partitions, partition)
+	}
+
+	return plan, nil
+}
+
+// committedOffsetsPlan lists every committed offset of the group that is going to be deleted, optionally of one topic.
+func (p *Project) committedOffsetsPlan(
+	ctx context.Context,
+	groupName string,
+	operation ConsumerGroupOperation,
+	topic string,
+) (*ConsumerGroupOperationPlan, error) {
+	commits, err := p.client.FetchOffsets(ctx, groupName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch consumer group offsets: %w", err)
+	}
+
+	if topic != "" {
+		commits.KeepFunc(func(commit kadm.OffsetResponse) bool {
+			return commit.Topic == topic
+		})
+
+		if len(commits) == 0 {
+			return nil, fmt.Errorf(
+				"%w: %s has no offsets for %s",
+				errNoConsumerGroupOffsetsToOperate,
+				groupName,
+				topic,
+			)
+		}
+	}
+
+	plan := &ConsumerGroupOperationPlan{
+		GroupName: groupName,
+		Operation: operation,
+	}
+
+	if len(commits) == 0 {
+		return plan, nil
+	}
+
+	topics := commits.Partitions().Topics()
+
+	startOffsets, err := p.client.ListStartOffsets(ctx, topics...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list start offsets: %w", err)
+	}
+
+	endOffsets, err := p.client.ListEndOffsets(ctx, topics...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list end offsets: %w", err)
+	}
+
+	for _, commit := range commits.Sorted() {
+		partition := newConsumerGroupOperationPlanPartition(
+			commit.Topic,
+			commit.Partition,
+			commits,
+			startOffsets,
+			endOffsets,
+		)
+		partition.TargetOffset = -1
+
+		plan.Partitions = append(plan.Partitions, partition)
+	}
+
+	return plan, nil
+}
+
+func newConsumerGroupOperationPlanPartition(
+	topic string,
+	partitionID int32,
+	commits kadm.OffsetResponses,
+	startOffsets,
+	endOffsets kadm.ListedOffsets,
+) *ConsumerGroupOperationPlanPartition {
+	partition := &ConsumerGroupOperationPlanPartition{
+		Topic:          topic,
+		PartitionID:    int(partitionID),
+		CurrentOffset:  -1,
+		LogStartOffset: -1,
+		LogEndOffset:   -1,
+	}
+
+	if commit, ok := commits.Lookup(topic, partitionID); ok && commit.Err == nil {
+		partition.CurrentOffset = commit.At
+	}
+
+	if startOffset, ok := startOffsets.Lookup(topic, partitionID); ok && startOffset.Err == nil {
+		partition.LogStartOffset = startOffset.Offset
+	}
+
+	if endOffset, ok := endOffsets.Lookup(topic, partitionID); ok && endOffset.Err == nil {
+		partition.LogEndOffset = endOffset.Offset
+	}
+
+	return partition
+}
@@ -0,0 +1,291 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+var errInvalidConsumingFilter = errors.New("invalid consuming filter")
+
+type jsonPathCondition struct {
+	expression jp.Expr
+	value      string
+}
+
+// topicConsumingFilter is the compiled TopicConsumingFilter.
+type topicConsumingFilter struct {
+	keyContains   string
+	keyRegex      *regexp.Regexp
+	valueContains string
+	valueRegex    *regexp.Regexp
+	jsonPaths     []*jsonPathCondition
+	headers       []*MessageHeader
+	partitionIDs  map[int32]bool
+	timeTo        time.Time
+	offsetTo      *int64
+}
+
+func newTopicConsumingFilter(filter *TopicConsumingFilter) (*topicConsumingFilter, error) {
+	if filter == nil {
+		return &topicConsumingFilter{}, nil
+	}
+
+	consumingFilter := &topicConsumingFilter{
+		keyContains:   filter.KeyContains,
+		valueContains: filter.ValueContains,
+		headers:       filter.Headers,
+		offsetTo:      filter.OffsetTo,
+	}
+
+	var err error
+
+	consumingFilter.keyRegex, err = compileFilterRegex(filter.KeyRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	consumingFilter.valueRegex, err = compileFilterRegex(filter.ValueRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, condition := range filter.ValueJSONPaths {
+		expression, err := jp.ParseString(condition.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: json path %s: %w", errInvalidConsumingFilter, condition.Path, err)
+		}
+
+		consumingFilter.jsonPaths = append(
+			consumingFilter.jsonPaths,
+			&jsonPathCondition{expression: expression, value: condition.Value},
+		)
+	}
+
+	if len(filter.PartitionIDs) > 0 {
+		consumingFilter.partitionIDs = make(map[int32]bool, len(filter.PartitionIDs))
+
+		for _, partitionID := range filter.PartitionIDs {
+			consumingFilter.partitionIDs[int32(partitionID)] = true
+		}
+	}
+
+	if filter.TimeTo != "" {
+		consumingFilter.timeTo, err = time.Parse(consumingTimeFromLayout, filter.TimeTo)
+		if err != nil {
+			return nil, fmt.Errorf("%w: time to: %w", errInvalidConsumingFilter, err)
+		}
+	}
+
+	return consumingFilter, nil
+}
+
+func compileFilterRegex(expression string) (*regexp.Regexp, error) {
+	if expression == "" {
+		return nil, nil
+	}
+
+	compiled, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: regex %s: %w", errInvalidConsumingFilter, expression, err)
+	}
+
+	return compiled, nil
+}
+
+func (f *topicConsumingFilter) isBounded() bool {
+	return !f.timeTo.IsZero() || f.offsetTo != nil
+}
+
+func (f *topicConsumingFilter) isPastBound(record *kgo.Record) bool {
+	if !f.timeTo.IsZero() && record.Timestamp.After(f.timeTo) {
+		return true
+	}
+
+	return f.offsetTo != nil && record.Offset > *f.offsetTo
+}
+
+// matches checks the record against the decoded key and value, the cheapest conditions go first.
+func (f *topicConsumingFilter) matches(record *kgo.Record, key, value string) bool {
+	if !f.matchesHeaders(record.Headers) {
+		return false
+	}
+
+	if f.keyContains != "" && !strings.Contains(key, f.keyContains) {
+		return false
+	}
+
+	if f.valueContains != "" && !strings.Contains(value, f.valueContains) {
+		return false
+	}
+
+	if f.keyRegex != nil && !f.keyRegex.MatchString(key) {
+		return false
+	}
+
+	if f.valueRegex != nil && !f.valueRegex.MatchString(value) {
+		return false
+	}
+
+	return f.matchesJSONPaths(value)
+}
+
+func (f *topicConsumingFilter) matchesHeaders(recordHeaders []kgo.RecordHeader) bool {
+	for _, header := range f.headers {
+		isFound := false
+
+		for _, recordHeader := range recordHeaders {
+			isValueMatched := header.Value == "" || string(recordHeader.Value) == header.Value
+			if recordHeader.Key == header.Key && isValueMatched {
+				isFound = true
+
+				break
+			}
+		}
+
+		if !isFound {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *topicConsumingFilter) matchesJSONPaths(value string) bool {
+	if len(f.jsonPaths) == 0 {
+		return true
+	}
+
+	// numbers stay as written, so that large ids compare exactly
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var data interface{}
+
+	err := decoder.Decode(&data)
+	if err != nil {
+		return false
+	}
+
+	for _, condition := range f.jsonPaths {
+		if !condition.matches(data) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *jsonPathCondition) matches(data interface{}) bool {
+	for _, result := range c.expression.Get(data) {
+		if text, ok := result.(string); ok {
+			if text == c.value {
+				return true
+			}
+
+			continue
+		}
+
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			continue
+		}
+
+		if bytes.Equal(encoded, []byte(c.value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// consumingProgress counts the consumed records and tracks which partitions a bounded consuming still reads.
+type consumingProgress struct {
+	TopicConsumingProgress
+
+	filter *topicConsumingFilter
+	// endOffsets holds the end offset every unfinished partition reads up to, -1 when only the bound finishes it.
+	endOffsets map[int32]int64
+}
+
+func newConsumingProgress(filter *topicConsumingFilter, startOffsets, endOffsets map[int32]int64) *consumingProgress {
+	progress := &consumingProgress{filter: filter}
+
+	if !filter.isBounded() {
+		return progress
+	}
+
+	progress.endOffsets = make(map[int32]int64, len(endOffsets))
+
+	for partitionID, endOffset := range endOffsets {
+		startOffset, ok := startOffsets[partitionID]
+
+		switch {
+		case !ok:
+			progress.endOffsets[partitionID] = -1
+		case startOffset < endOffset:
+			progress.endOffsets[partitionID] = endOffset
+		}
+	}
+
+	progress.IsFinished = len(progress.endOffsets) == 0
+
+	return progress
+}
+
+// isConsumed tells whether records of the partition are still wanted, fetches may be buffered past a finish.
+func (p *consumingProgress) isConsumed(partitionID int32) bool {
+	if p.endOffsets == nil {
+		return true
+	}
+
+	_, ok := p.endOffsets[partitionID]
+
+	return ok
+}
+
+// scan returns false for a record past the bound, which finishes its partition.
+func (p *consumingProgress) scan(record *kgo.Record) bool {
+	if p.filter.isPastBound(record) {
+		p.finish(record.Partition)
+
+		return false
+	}
+
+	p.ScannedCount++
+
+	if p.endOffsets != nil {
+		endOffset := p.endOffsets[record.Partition]
+		if endOffset >= 0 && record.Offset+1 >= endOffset {
+			p.finish(record.Partition)
+		}
+	}
+
+	return true
+}
+
+func (p *consumingProgress) finish(partitionID int32) {
+	delete(p.endOffsets, partitionID)
+
+	p.IsFinished = len(p.endOffsets) == 0
+}
+
+// finishedPartitions returns the partitions out of the given ones that are finished.
+func (p *consumingProgress) finishedPartitions(partitionIDs []int32) []int32 {
+	var finished []int32
+
+	for _, partitionID := range partitionIDs {
+		if !p.isConsumed(partitionID) {
+			finished = append(finished, partitionID)
+		}
+	}
+
+	return finished
+}
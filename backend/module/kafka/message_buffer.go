@@ -0,0 +1,169 @@
+package kafka
+
+import (
+	"sync"
+)
+
+const (
+	topicMessageBufferDefaultSize = 10000
+	topicMessagesDefaultPageSize  = 100
+)
+
+// topicMessageBuffer keeps the last consumed messages along with the latest consuming output and progress,
+// the consuming goroutine fills it and the emitting goroutine drains what has not been emitted yet.
+type topicMessageBuffer struct {
+	mutex sync.Mutex
+	// messages is a ring, next is where the following message goes
+	messages []*TopicMessage
+	next     int
+	count    int
+	// sequences start at 1, so that 0 means no message
+	lastSequence    int64
+	emittedSequence int64
+
+	output            *TopicOutput
+	isOutputEmitted   bool
+	progress          TopicConsumingProgress
+	isProgressEmitted bool
+}
+
+func newTopicMessageBuffer(size int) *topicMessageBuffer {
+	if size <= 0 {
+		size = topicMessageBufferDefaultSize
+	}
+
+	return &topicMessageBuffer{
+		messages: make([]*TopicMessage, size),
+	}
+}
+
+// push stores the consumed messages with snapshots of the output and progress they were consumed with.
+func (b *topicMessageBuffer) push(messages []*TopicMessage, output *TopicOutput, progress TopicConsumingProgress) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, message := range messages {
+		b.lastSequence++
+		message.Sequence = b.lastSequence
+
+		b.messages[b.next] = message
+		b.next = (b.next + 1) % len(b.messages)
+
+		if b.count < len(b.messages) {
+			b.count++
+		}
+	}
+
+	b.output = output
+	b.isOutputEmitted = false
+
+	if b.progress != progress {
+		b.progress = progress
+		b.isProgressEmitted = false
+	}
+}
+
+// takeUnemitted returns up to limit of the newest messages not emitted yet, the older ones are skipped
+// and stay available for paging as long as the buffer holds them. Nothing is returned for what has not changed.
+func (b *topicMessageBuffer) takeUnemitted(limit int) (*TopicConsumingOutput, *TopicConsumingProgress) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var consumingOutput *TopicConsumingOutput
+
+	if !b.isOutputEmitted || b.lastSequence > b.emittedSequence {
+		count := b.lastSequence - b.emittedSequence
+		messages := b.newest(int(min64(count, int64(limit))))
+
+		consumingOutput = &TopicConsumingOutput{
+			Messages:     messages,
+			SkippedCount: count - int64(len(messages)),
+			Topic:        b.output,
+		}
+
+		b.emittedSequence = b.lastSequence
+		b.isOutputEmitted = true
+	}
+
+	var progress *TopicConsumingProgress
+
+	if !b.isProgressEmitted {
+		progressCopy := b.progress
+		progress = &progressCopy
+
+		b.isProgressEmitted = true
+	}
+
+	return consumingOutput, progress
+}
+
+// page returns up to limit messages consumed before the sequence, 0 pages from the newest message.
+func (b *topicMessageBuffer) page(beforeSequence int64, limit int) *TopicMessagesPage {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if limit <= 0 {
+		limit = topicMessagesDefaultPageSize
+	}
+
+	oldestSequence := b.lastSequence - int64(b.count) + 1
+
+	if beforeSequence <= 0 || beforeSequence > b.lastSequence+1 {
+		beforeSequence = b.lastSequence + 1
+	}
+
+	page := &TopicMessagesPage{
+		BufferedCount: b.count,
+		DroppedCount:  oldestSequence - 1,
+		Topic:         b.output,
+	}
+
+	availableCount := beforeSequence - oldestSequence
+	if availableCount <= 0 {
+		return page
+	}
+
+	count := min64(availableCount, int64(limit))
+	skipped := int(b.lastSequence + 1 - beforeSequence)
+
+	page.Messages = b.newest(int(count) + skipped)[:count]
+	page.HasMore = availableCount > count
+
+	return page
+}
+
+// newest returns the newest count messages in the order they were consumed.
+func (b *topicMessageBuffer) newest(count int) []*TopicMessage {
+	if count > b.count {
+		count = b.count
+	}
+
+	messages := make([]*TopicMessage, 0, count)
+
+	for i := count; i > 0; i-- {
+		messages = append(messages, b.messages[(b.next-i+len(b.messages))%len(b.messages)])
+	}
+
+	return messages
+}
+
+// copy lets the consuming goroutine keep updating the output while a snapshot of it is marshaled.
+func (o *TopicOutput) copy() *TopicOutput {
+	outputCopy := *o
+	outputCopy.Partitions = make([]*TopicPartition, 0, len(o.Partitions))
+
+	for _, partition := range o.Partitions {
+		partitionCopy := *partition
+		outputCopy.Partitions = append(outputCopy.Partitions, &partitionCopy)
+	}
+
+	return &outputCopy
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
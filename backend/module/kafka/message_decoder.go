@@ -0,0 +1,65 @@
+package kafka
+
+import (
+	"encoding/hex"
+
+	"github.com/jhump/protoreflect/desc"
+)
+
+// messageDecoder renders consumed keys and values as text, it is built from the project settings once per consuming.
+type messageDecoder struct {
+	schemaRegistry *schemaRegistryClient
+	// keyMessageType and valueMessageType come from the topic proto mapping and take precedence over the registry.
+	keyMessageType   *desc.MessageDescriptor
+	valueMessageType *desc.MessageDescriptor
+}
+
+func (d *messageDecoder) decodeKey(data []byte) (string, *MessageSchema) {
+	return d.decode(data, d.keyMessageType)
+}
+
+func (d *messageDecoder) decodeValue(data []byte) (string, *MessageSchema) {
+	return d.decode(data, d.valueMessageType)
+}
+
+// decode falls back to the raw bytes whenever the data cannot be decoded, the schema then carries the error.
+func (d *messageDecoder) decode(data []byte, messageType *desc.MessageDescriptor) (string, *MessageSchema) {
+	// a null key or value has nothing to decode
+	if messageType != nil && data != nil {
+		return decodeMappedProtobuf(messageType, data)
+	}
+
+	if d.schemaRegistry == nil {
+		return string(data), nil
+	}
+
+	text, schema, err := d.schemaRegistry.decode(data)
+	if schema == nil {
+		return string(data), nil
+	}
+
+	if err != nil {
+		schema.Error = err.Error()
+
+		return string(data), schema
+	}
+
+	return text, schema
+}
+
+// decodeMappedProtobuf renders raw protobuf as JSON, or as hex when the data is not of the message type.
+func decodeMappedProtobuf(messageType *desc.MessageDescriptor, data []byte) (string, *MessageSchema) {
+	schema := &MessageSchema{
+		Type:        SchemaTypeProtobuf,
+		MessageType: messageType.GetFullyQualifiedName(),
+	}
+
+	text, err := decodeProtobuf(messageType, data)
+	if err != nil {
+		schema.Error = err.Error()
+
+		return hex.EncodeToString(data), schema
+	}
+
+	return text, schema
+}
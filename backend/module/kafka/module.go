@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 
 	"github.com/catake-com/multibase/backend/state"
 )
@@ -90,6 +91,20 @@ func (m *Module) Connect(projectID string) (*State, error) {
 	return project.state, nil
 }
 
+func (m *Module) Overview(projectID string) (*TabOverviewData, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.Overview()
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 func (m *Module) Topics(projectID string) (*TabTopicsData, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -104,6 +119,90 @@ func (m *Module) Topics(projectID string) (*TabTopicsData, error) {
 	return data, nil
 }
 
+func (m *Module) TopicDetails(projectID, topicName string) (*TopicDetails, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.TopicDetails(topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) CreateTopic(projectID string, creation *TopicCreation) (*TopicDetails, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.CreateTopic(creation)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) DeleteTopic(projectID, topicName string) (*TabTopicsData, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.DeleteTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) AddTopicPartitions(projectID, topicName string, count int) (*TopicDetails, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.AddTopicPartitions(topicName, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) TopicConfigs(projectID, topicName string) (*TopicConfigs, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.TopicConfigs(topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) AlterTopicConfigs(projectID, topicName string, changes []*ConfigChange) (*TopicConfigs, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.AlterTopicConfigs(topicName, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 func (m *Module) Brokers(projectID string) (*TabBrokersData, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -118,6 +217,20 @@ func (m *Module) Brokers(projectID string) (*TabBrokersData, error) {
 	return data, nil
 }
 
+func (m *Module) BrokerDetails(projectID string, brokerID int) (*BrokerDetails, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.BrokerDetails(brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 func (m *Module) Consumers(projectID string) (*TabConsumersData, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -132,19 +245,118 @@ func (m *Module) Consumers(projectID string) (*TabConsumersData, error) {
 	return data, nil
 }
 
+func (m *Module) ConsumerGroup(projectID, groupName string) (*ConsumerGroup, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.ConsumerGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) StartConsumerGroupWatching(projectID, groupName string, intervalMs int) error {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return err
+	}
+
+	err = project.StartConsumerGroupWatching(m.AppCtx, groupName, intervalMs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Module) StopConsumerGroupWatching(projectID string) error {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return err
+	}
+
+	err = project.StopConsumerGroupWatching()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Module) ResetConsumerGroupOffsets(
+	projectID,
+	groupName string,
+	reset *ConsumerGroupOffsetsReset,
+	confirmationToken string,
+) (*ConsumerGroupOperationPlan, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := project.ResetConsumerGroupOffsets(groupName, reset, confirmationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (m *Module) DeleteConsumerGroupOffsets(
+	projectID,
+	groupName,
+	topic,
+	confirmationToken string,
+) (*ConsumerGroupOperationPlan, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := project.DeleteConsumerGroupOffsets(groupName, topic, confirmationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (m *Module) DeleteConsumerGroup(
+	projectID,
+	groupName,
+	confirmationToken string,
+) (*ConsumerGroupOperationPlan, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := project.DeleteConsumerGroup(groupName, confirmationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
 func (m *Module) StartTopicConsuming(
 	projectID string,
 	consumingStrategy TopicConsumingStrategy,
 	topic,
 	timeFrom string,
 	offsetValue int64,
+	filter *TopicConsumingFilter,
 ) (*TopicOutput, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := project.StartTopicConsuming(m.AppCtx, consumingStrategy, topic, timeFrom, offsetValue)
+	data, err := project.StartTopicConsuming(m.AppCtx, consumingStrategy, topic, timeFrom, offsetValue, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +378,213 @@ func (m *Module) StopTopicConsuming(projectID string) error {
 	return nil
 }
 
+func (m *Module) TopicMessages(projectID string, beforeSequence int64, limit int) (*TopicMessagesPage, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.TopicMessages(beforeSequence, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) ProduceMessage(
+	projectID,
+	topic string,
+	message *ProduceMessage,
+	options *ProduceOptions,
+) (*ProduceResult, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.ProduceMessage(topic, message, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) ProduceMessages(
+	projectID,
+	topic string,
+	messages []*ProduceMessage,
+	options *ProduceOptions,
+) (*ProduceOutput, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.ProduceMessages(topic, messages, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) ProduceMessagesFromFile(projectID, topic string, options *ProduceOptions) (*ProduceOutput, error) {
+	filePath, err := runtime.OpenFileDialog(m.AppCtx, runtime.OpenDialogOptions{
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Messages (*.json, *.ndjson, *.jsonl)", Pattern: "*.json;*.ndjson;*.jsonl"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open messages file: %w", err)
+	}
+
+	if filePath == "" {
+		return nil, nil
+	}
+
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := project.ProduceMessagesFromFile(topic, filePath, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (m *Module) OpenProtoFile(projectID string) (*State, error) {
+	protoFilePath, err := runtime.OpenFileDialog(m.AppCtx, runtime.OpenDialogOptions{
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Proto Files (*.proto)", Pattern: "*.proto;"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open proto file: %w", err)
+	}
+
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if protoFilePath == "" {
+		return project.state, nil
+	}
+
+	err = project.OpenProtoFile(protoFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.state, nil
+}
+
+func (m *Module) OpenProtoImportPath(projectID string) (*State, error) {
+	importPath, err := runtime.OpenDirectoryDialog(m.AppCtx, runtime.OpenDialogOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import path: %w", err)
+	}
+
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if importPath == "" {
+		return project.state, nil
+	}
+
+	err = project.OpenProtoImportPath(importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.state, nil
+}
+
+func (m *Module) OpenProtoDescriptorSet(projectID string) (*State, error) {
+	descriptorSetPath, err := runtime.OpenFileDialog(m.AppCtx, runtime.OpenDialogOptions{
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Descriptor Sets (*.protoset, *.pb, *.desc)", Pattern: "*.protoset;*.pb;*.desc"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open descriptor set: %w", err)
+	}
+
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if descriptorSetPath == "" {
+		return project.state, nil
+	}
+
+	err = project.OpenProtoDescriptorSet(descriptorSetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.state, nil
+}
+
+func (m *Module) RemoveProtoImportPath(projectID, importPath string) (*State, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.RemoveProtoImportPath(importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.state, nil
+}
+
+func (m *Module) DeleteAllProtoFiles(projectID string) (*State, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.DeleteAllProtoFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return project.state, nil
+}
+
+func (m *Module) ProtoMessageTypes(projectID string) ([]string, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.ProtoMessageTypes(), nil
+}
+
+func (m *Module) SaveTopicProtoMapping(projectID string, mapping *TopicProtoMapping) (*State, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveTopicProtoMapping(mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.state, nil
+}
+
 func (m *Module) ProjectState(projectID string) (*State, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -208,9 +627,23 @@ func (m *Module) fetchProject(projectID string) (*Project, error) {
 	}
 
 	projectState.CurrentTab = TabOverview
+	projectState.migrateAuthMethod()
 	project.state = projectState
 	project.stateStorage = m.stateStorage
 	project.appLogger = m.appLogger
+	project.schemaRegistry = newSchemaRegistryClient(projectState.SchemaRegistry)
+
+	// proto files may have moved since the project was saved, the project stays usable without them
+	project.protoMessageTypes, err = newProtoMessageTypes(
+		projectState.ProtoImportPathList,
+		projectState.ProtoFileList,
+		projectState.ProtoDescriptorSetList,
+	)
+	if err != nil {
+		m.appLogger.Error(
+			fmt.Errorf("failed to load proto message types of kafka project %s: %w", projectID, err),
+		)
+	}
 
 	m.projectsMutex.Lock()
 	m.projects[projectID] = project
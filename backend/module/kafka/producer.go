@@ -0,0 +1,487 @@
+package kafka
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const kafkaProduceTimeout = 30 * time.Second
+
+var (
+	errUnknownProduceCompression = errors.New("unknown produce compression")
+	errUnknownProduceAcks        = errors.New("unknown produce acks")
+	errNoMessagesToProduce       = errors.New("no messages to produce")
+	errTopicNotFound             = errors.New("topic not found")
+	errPartitionNotFound         = errors.New("partition not found")
+)
+
+type explicitPartitionKey struct{}
+
+func (p *Project) ProduceMessage(
+	topic string,
+	message *ProduceMessage,
+	options *ProduceOptions,
+) (*ProduceResult, error) {
+	results, err := p.produce(topic, []*ProduceMessage{message}, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if results[0].Err != nil {
+		return nil, fmt.Errorf("failed to produce a message: %w", results[0].Err)
+	}
+
+	return newProduceResult(results[0]), nil
+}
+
+func (p *Project) ProduceMessages(
+	topic string,
+	messages []*ProduceMessage,
+	options *ProduceOptions,
+) (*ProduceOutput, error) {
+	results, err := p.produce(topic, messages, options)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ProduceOutput{
+		Count:   len(results),
+		Results: make([]*ProduceResult, 0, len(results)),
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			output.ErrorCount++
+		}
+
+		output.Results = append(output.Results, newProduceResult(result))
+	}
+
+	return output, nil
+}
+
+func (p *Project) ProduceMessagesFromFile(topic, filePath string, options *ProduceOptions) (*ProduceOutput, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read messages file: %w", err)
+	}
+
+	messages, err := parseProduceMessages(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ProduceMessages(topic, messages, options)
+}
+
+func (p *Project) produce(
+	topic string,
+	messages []*ProduceMessage,
+	options *ProduceOptions,
+) (kgo.ProduceResults, error) {
+	if len(messages) == 0 {
+		return nil, errNoMessagesToProduce
+	}
+
+	records, err := produceRecords(topic, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	p.stateMutex.RLock()
+	clientOptions, err := p.clientOptions()
+	schemaRegistry := p.schemaRegistry
+	p.stateMutex.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = encodeProduceRecords(schemaRegistry, records, options)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.fetchProducerClient(clientOptions, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), kafkaProduceTimeout)
+	defer cancelFunc()
+
+	err = validateProducePartitions(ctx, client, topic, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	// results come in the order records are acknowledged, put them back in the order of messages
+	recordIndexes := make(map[*kgo.Record]int, len(records))
+	for i, record := range records {
+		recordIndexes[record] = i
+	}
+
+	results := make(kgo.ProduceResults, len(records))
+	for _, result := range client.ProduceSync(ctx, records...) {
+		results[recordIndexes[result.Record]] = result
+	}
+
+	return results, nil
+}
+
+// fetchProducerClient reuses the project producer while the options it was built with stay the same.
+func (p *Project) fetchProducerClient(clientOptions []kgo.Opt, options *ProduceOptions) (*kgo.Client, error) {
+	key := options.producerKey()
+
+	producerOptions, err := key.producerOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	p.producerMutex.Lock()
+	defer p.producerMutex.Unlock()
+
+	if p.producerClient != nil && p.producerClientKey == key {
+		return p.producerClient, nil
+	}
+
+	p.resetProducerClient()
+
+	client, err := kgo.NewClient(append(clientOptions, producerOptions...)...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot establish kafka connection: %w", err)
+	}
+
+	p.producerClient = client
+	p.producerClientKey = key
+
+	return client, nil
+}
+
+// closeProducerClient closes the project producer, the next produce connects with the current settings.
+func (p *Project) closeProducerClient() {
+	p.producerMutex.Lock()
+	defer p.producerMutex.Unlock()
+
+	p.resetProducerClient()
+}
+
+func (p *Project) resetProducerClient() {
+	if p.producerClient == nil {
+		return
+	}
+
+	p.producerClient.Close()
+	p.producerClient = nil
+}
+
+// producerClientKey holds the options a producer client is built with.
+type producerClientKey struct {
+	compression ProduceCompression
+	acks        ProduceAcks
+}
+
+func (o *ProduceOptions) producerKey() producerClientKey {
+	if o == nil {
+		return producerClientKey{}
+	}
+
+	return producerClientKey{compression: o.Compression, acks: o.Acks}
+}
+
+func (o producerClientKey) producerOptions() ([]kgo.Opt, error) {
+	options := []kgo.Opt{
+		kgo.RecordPartitioner(explicitPartitioner{kgo.StickyKeyPartitioner(nil)}),
+	}
+
+	switch o.compression {
+	case ProduceCompressionNone, "":
+		options = append(options, kgo.ProducerBatchCompression(kgo.NoCompression()))
+	case ProduceCompressionGzip:
+		options = append(options, kgo.ProducerBatchCompression(kgo.GzipCompression()))
+	case ProduceCompressionSnappy:
+		options = append(options, kgo.ProducerBatchCompression(kgo.SnappyCompression()))
+	case ProduceCompressionLZ4:
+		options = append(options, kgo.ProducerBatchCompression(kgo.Lz4Compression()))
+	case ProduceCompressionZstd:
+		options = append(options, kgo.ProducerBatchCompression(kgo.ZstdCompression()))
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownProduceCompression, o.compression)
+	}
+
+	// idempotent writes require acks from all in-sync replicas
+	switch o.acks {
+	case ProduceAcksAll, "":
+		options = append(options, kgo.RequiredAcks(kgo.AllISRAcks()))
+	case ProduceAcksLeader:
+		options = append(options, kgo.RequiredAcks(kgo.LeaderAck()), kgo.DisableIdempotentWrite())
+	case ProduceAcksNone:
+		options = append(options, kgo.RequiredAcks(kgo.NoAck()), kgo.DisableIdempotentWrite())
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownProduceAcks, o.acks)
+	}
+
+	return options, nil
+}
+
+func produceRecords(topic string, messages []*ProduceMessage) ([]*kgo.Record, error) {
+	records := make([]*kgo.Record, 0, len(messages))
+
+	for i, message := range messages {
+		record := &kgo.Record{Topic: topic}
+
+		// a null value is a tombstone, unlike an empty one
+		if !message.IsNullValue {
+			record.Value = []byte(message.Value)
+		}
+
+		if message.Key != "" {
+			record.Key = []byte(message.Key)
+		}
+
+		for _, header := range message.Headers {
+			recordHeader := kgo.RecordHeader{Key: header.Key, Value: []byte(header.Value)}
+			record.Headers = append(record.Headers, recordHeader)
+		}
+
+		if message.Timestamp != "" {
+			timestamp, err := time.Parse(consumingTimeFromLayout, message.Timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse timestamp of message %d: %w", i, err)
+			}
+
+			record.Timestamp = timestamp
+		}
+
+		if message.Partition != nil {
+			record.Partition = *message.Partition
+			record.Context = context.WithValue(context.Background(), explicitPartitionKey{}, true)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func encodeProduceRecords(schemaRegistry *schemaRegistryClient, records []*kgo.Record, options *ProduceOptions) error {
+	if options == nil || (options.KeySchemaSubject == "" && options.ValueSchemaSubject == "") {
+		return nil
+	}
+
+	if schemaRegistry == nil {
+		return errSchemaRegistryNotConfigured
+	}
+
+	for i, record := range records {
+		if options.KeySchemaSubject != "" && record.Key != nil {
+			key, err := schemaRegistry.encode(options.KeySchemaSubject, string(record.Key))
+			if err != nil {
+				return fmt.Errorf("cannot encode key of message %d: %w", i, err)
+			}
+
+			record.Key = key
+		}
+
+		if options.ValueSchemaSubject != "" && record.Value != nil {
+			value, err := schemaRegistry.encode(options.ValueSchemaSubject, string(record.Value))
+			if err != nil {
+				return fmt.Errorf("cannot encode value of message %d: %w", i, err)
+			}
+
+			record.Value = value
+		}
+	}
+
+	return nil
+}
+
+func validateProducePartitions(
+	ctx context.Context,
+	client *kgo.Client,
+	topic string,
+	messages []*ProduceMessage,
+) error {
+	var hasExplicitPartition bool
+
+	for _, message := range messages {
+		hasExplicitPartition = hasExplicitPartition || message.Partition != nil
+	}
+
+	if !hasExplicitPartition {
+		return nil
+	}
+
+	kafkaTopics, err := kadm.NewClient(client).ListTopics(ctx, topic)
+	if err != nil {
+		return fmt.Errorf("failed to list topics: %w", err)
+	}
+
+	kafkaTopic, ok := kafkaTopics[topic]
+	if !ok || kafkaTopic.Err != nil {
+		return fmt.Errorf("%w: %s", errTopicNotFound, topic)
+	}
+
+	for _, message := range messages {
+		if message.Partition == nil {
+			continue
+		}
+
+		if _, ok := kafkaTopic.Partitions[*message.Partition]; !ok {
+			return fmt.Errorf("%w: %s/%d", errPartitionNotFound, topic, *message.Partition)
+		}
+	}
+
+	return nil
+}
+
+func newProduceResult(result kgo.ProduceResult) *ProduceResult {
+	produceResult := &ProduceResult{
+		PartitionID: int(result.Record.Partition),
+		Offset:      result.Record.Offset,
+	}
+
+	if !result.Record.Timestamp.IsZero() {
+		produceResult.TimestampFormatted = result.Record.Timestamp.Format(consumingTimeFromLayout)
+	}
+
+	if result.Err != nil {
+		produceResult.Error = result.Err.Error()
+	}
+
+	return produceResult
+}
+
+// produceFileMessage is a message as it is written in a batch file, where the key and the value
+// can be either strings or any JSON that is then sent as is.
+type produceFileMessage struct {
+	Partition *int32            `json:"partition"`
+	Key       json.RawMessage   `json:"key"`
+	Value     json.RawMessage   `json:"value"`
+	Headers   map[string]string `json:"headers"`
+	Timestamp string            `json:"timestamp"`
+}
+
+// parseProduceMessages reads either a JSON array of messages or one message per line (NDJSON).
+func parseProduceMessages(data []byte) ([]*ProduceMessage, error) {
+	var fileMessages []*produceFileMessage
+
+	data = bytes.TrimSpace(data)
+
+	if bytes.HasPrefix(data, []byte("[")) {
+		err := json.Unmarshal(data, &fileMessages)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse messages file: %w", err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(nil, len(data)+1)
+
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+
+			fileMessage := &produceFileMessage{}
+
+			err := json.Unmarshal(scanner.Bytes(), fileMessage)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse line %d of messages file: %w", line, err)
+			}
+
+			fileMessages = append(fileMessages, fileMessage)
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read messages file: %w", err)
+		}
+	}
+
+	messages := make([]*ProduceMessage, 0, len(fileMessages))
+
+	for _, fileMessage := range fileMessages {
+		message := &ProduceMessage{
+			Partition:   fileMessage.Partition,
+			Key:         rawMessageText(fileMessage.Key),
+			Value:       rawMessageText(fileMessage.Value),
+			IsNullValue: string(fileMessage.Value) == "null",
+			Timestamp:   fileMessage.Timestamp,
+		}
+
+		for key, value := range fileMessage.Headers {
+			message.Headers = append(message.Headers, &MessageHeader{Key: key, Value: value})
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func rawMessageText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, raw); err != nil {
+		return string(raw)
+	}
+
+	return compacted.String()
+}
+
+// explicitPartitioner sends records with a user chosen partition there and leaves the rest to the wrapped partitioner.
+type explicitPartitioner struct {
+	kgo.Partitioner
+}
+
+func (p explicitPartitioner) ForTopic(topic string) kgo.TopicPartitioner {
+	return explicitTopicPartitioner{p.Partitioner.ForTopic(topic)}
+}
+
+type explicitTopicPartitioner struct {
+	kgo.TopicPartitioner
+}
+
+func (p explicitTopicPartitioner) RequiresConsistency(record *kgo.Record) bool {
+	return isExplicitPartition(record) || p.TopicPartitioner.RequiresConsistency(record)
+}
+
+func (p explicitTopicPartitioner) Partition(record *kgo.Record, n int) int {
+	if isExplicitPartition(record) {
+		return int(record.Partition)
+	}
+
+	return p.TopicPartitioner.Partition(record, n)
+}
+
+func (p explicitTopicPartitioner) OnNewBatch() {
+	if onNewBatch, ok := p.TopicPartitioner.(kgo.TopicPartitionerOnNewBatch); ok {
+		onNewBatch.OnNewBatch()
+	}
+}
+
+func isExplicitPartition(record *kgo.Record) bool {
+	if record.Context == nil {
+		return false
+	}
+
+	isExplicit, _ := record.Context.Value(explicitPartitionKey{}).(bool)
+
+	return isExplicit
+}
@@ -11,10 +11,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"github.com/twmb/franz-go/pkg/kadm"
 	"github.com/twmb/franz-go/pkg/kgo"
-	"github.com/twmb/franz-go/pkg/sasl/plain"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
 	"github.com/catake-com/multibase/backend/state"
@@ -23,6 +23,9 @@ import (
 const (
 	kafkaConnectionTimeout = 10 * time.Second
 
+	topicConsumingEmitInterval  = 250 * time.Millisecond
+	topicConsumingEmitBatchSize = 500
+
 	consumingTimeFromLayout = "2006-01-02 15:04:05 Z07:00"
 )
 
@@ -30,6 +33,8 @@ var (
 	errNoStartOffsetFound            = errors.New("no start offset found")
 	errNoEndOffsetFound              = errors.New("no end offset found")
 	errUnknownKafkaConsumingStrategy = errors.New("unknown kafka consuming strategy")
+	errTopicNotConsumed              = errors.New("topic is not consumed")
+	errInvalidConsumingBufferSize    = errors.New("invalid consuming buffer size")
 )
 
 type Project struct {
@@ -40,15 +45,25 @@ type Project struct {
 	client               *kadm.Client
 	topicConsumingClient *kgo.Client
 	topicConsumingCancel context.CancelFunc
+	topicMessageBuffer   *topicMessageBuffer
+	producerClient       *kgo.Client
+	producerClientKey    producerClientKey
+	producerMutex        sync.Mutex
+
+	consumerGroupWatchingCancel    context.CancelFunc
+	pendingConsumerGroupOperations map[string]*pendingConsumerGroupOperation
+	schemaRegistry                 *schemaRegistryClient
+	protoMessageTypes              *protoMessageTypes
 }
 
 func NewProject(projectID string, stateStorage *state.Storage, appLogger *logrus.Logger) (*Project, error) {
 	project := &Project{
 		state: &State{
-			ID:         projectID,
-			CurrentTab: TabOverview,
-			Address:    "0.0.0.0:9092",
-			AuthMethod: AuthMethodPlaintext,
+			ID:               projectID,
+			CurrentTab:       TabOverview,
+			Address:          "0.0.0.0:9092",
+			SecurityProtocol: SecurityProtocolPlaintext,
+			SASLMechanism:    SASLMechanismPlain,
 		},
 		stateStorage: stateStorage,
 		appLogger:    appLogger,
@@ -65,12 +80,55 @@ func (p *Project) SaveState(state *State) error {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
 
-	if state.AuthMethod == AuthMethodPlaintext {
+	state.migrateAuthMethod()
+
+	err := state.validateSecurity()
+	if err != nil {
+		return err
+	}
+
+	if !state.isSASL() {
 		state.AuthUsername = ""
 		state.AuthPassword = ""
+		state.AuthToken = ""
+	}
+
+	if state.ConsumingBufferSize < 0 {
+		return fmt.Errorf("%w: %d", errInvalidConsumingBufferSize, state.ConsumingBufferSize)
+	}
+
+	err = state.SchemaRegistry.validate()
+	if err != nil {
+		return err
+	}
+
+	// proto files are parsed again only when their settings change, so other settings save without them
+	messageTypes := p.protoMessageTypes
+	isProtoChanged := !p.state.hasProtoSources(state)
+
+	if isProtoChanged {
+		messageTypes, err = newProtoMessageTypes(
+			state.ProtoImportPathList,
+			state.ProtoFileList,
+			state.ProtoDescriptorSetList,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if isProtoChanged || !p.state.hasTopicProtoMappings(state) {
+		err = messageTypes.validateMappings(state.TopicProtoMappings)
+		if err != nil {
+			return err
+		}
 	}
 
 	p.state = state
+	p.schemaRegistry = newSchemaRegistryClient(state.SchemaRegistry)
+	p.protoMessageTypes = messageTypes
+
+	p.closeProducerClient()
 
 	return p.saveState()
 }
@@ -79,21 +137,9 @@ func (p *Project) Connect() error {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
 
-	tlsDialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: kafkaConnectionTimeout}}
-
-	options := []kgo.Opt{
-		kgo.SeedBrokers(p.state.Address),
-		kgo.Dialer(tlsDialer.DialContext),
-	}
-
-	if p.state.AuthMethod == AuthMethodSASLSSL {
-		options = append(
-			options,
-			kgo.SASL(plain.Auth{
-				User: p.state.AuthUsername,
-				Pass: p.state.AuthPassword,
-			}.AsMechanism()),
-		)
+	options, err := p.clientOptions()
+	if err != nil {
+		return err
 	}
 
 	client, err := kgo.NewClient(options...)
@@ -185,25 +231,16 @@ func (p *Project) Brokers() (*TabBrokersData, error) {
 
 	ctx := context.Background()
 
-	kafkaBrokers, err := p.client.ListBrokers(ctx)
+	metadata, err := p.client.BrokerMetadata(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list brokers: %w", err)
 	}
 
-	tabBrokersData.Count = len(kafkaBrokers)
-	tabBrokersData.List = make([]*TabBrokersDataBroker, 0, len(kafkaBrokers))
-
-	for _, kafkaBroker := range kafkaBrokers {
-		broker := &TabBrokersDataBroker{
-			ID:   int(kafkaBroker.NodeID),
-			Host: kafkaBroker.Host,
-			Port: int(kafkaBroker.Port),
-		}
-
-		if kafkaBroker.Rack != nil {
-			broker.Rack = *kafkaBroker.Rack
-		}
+	tabBrokersData.Count = len(metadata.Brokers)
+	tabBrokersData.List = make([]*TabBrokersDataBroker, 0, len(metadata.Brokers))
 
+	for _, kafkaBroker := range metadata.Brokers {
+		broker := newTabBrokersDataBroker(kafkaBroker, metadata.Controller)
 		tabBrokersData.List = append(tabBrokersData.List, broker)
 	}
 
@@ -231,8 +268,9 @@ func (p *Project) Consumers() (*TabConsumersData, error) {
 
 	for _, kafkaGroup := range kafkaGroups {
 		consumer := &TabConsumersDataConsumer{
-			Name:  kafkaGroup.Group,
-			State: kafkaGroup.State,
+			Name:         kafkaGroup.Group,
+			State:        kafkaGroup.State,
+			MembersCount: len(kafkaGroup.Members),
 		}
 
 		tabConsumersData.List = append(tabConsumersData.List, consumer)
@@ -241,20 +279,32 @@ func (p *Project) Consumers() (*TabConsumersData, error) {
 	return tabConsumersData, nil
 }
 
-// nolint: funlen, cyclop, gocognit
+// nolint: funlen, cyclop, gocognit, gocyclo
 func (p *Project) StartTopicConsuming(
 	ctx context.Context,
 	consumingStrategy TopicConsumingStrategy,
 	topic,
 	timeFrom string,
 	offsetValue int64,
+	filter *TopicConsumingFilter,
 ) (*TopicOutput, error) {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
 
-	tlsDialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: kafkaConnectionTimeout}}
+	keyMessageType, valueMessageType, err := p.protoMessageTypes.mapping(p.state.TopicProtoMappings, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	consumingFilter, err := newTopicConsumingFilter(filter)
+	if err != nil {
+		return nil, err
+	}
 
-	var kafkaOffset kgo.Offset
+	var (
+		kafkaOffset   kgo.Offset
+		timeFromMilli int64
+	)
 
 	switch consumingStrategy {
 	case TopicConsumingStrategyTime:
@@ -263,7 +313,8 @@ func (p *Project) StartTopicConsuming(
 			return nil, fmt.Errorf("cannot parse kafka consuming time from: %w", err)
 		}
 
-		kafkaOffset = kgo.NewOffset().AfterMilli(timeFromParsed.UnixMilli())
+		timeFromMilli = timeFromParsed.UnixMilli()
+		kafkaOffset = kgo.NewOffset().AfterMilli(timeFromMilli)
 	case TopicConsumingStrategyOffsetSpecific:
 		kafkaOffset = kgo.NewOffset().At(offsetValue)
 	case TopicConsumingStrategyOffsetNewest:
@@ -274,21 +325,23 @@ func (p *Project) StartTopicConsuming(
 		return nil, fmt.Errorf("%w: %s", errUnknownKafkaConsumingStrategy, consumingStrategy)
 	}
 
-	options := []kgo.Opt{
-		kgo.SeedBrokers(p.state.Address),
-		kgo.Dialer(tlsDialer.DialContext),
-		kgo.ConsumeTopics(topic),
-		kgo.ConsumeResetOffset(kafkaOffset),
-	}
-
-	if p.state.AuthMethod == AuthMethodSASLSSL {
-		options = append(
-			options,
-			kgo.SASL(plain.Auth{
-				User: p.state.AuthUsername,
-				Pass: p.state.AuthPassword,
-			}.AsMechanism()),
-		)
+	options, err := p.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	options = append(options, kgo.ConsumeResetOffset(kafkaOffset))
+
+	if consumingFilter.partitionIDs != nil {
+		partitionOffsets := make(map[int32]kgo.Offset, len(consumingFilter.partitionIDs))
+		for partitionID := range consumingFilter.partitionIDs {
+			partitionOffsets[partitionID] = kafkaOffset
+		}
+
+		topicPartitionOffsets := map[string]map[int32]kgo.Offset{topic: partitionOffsets}
+		options = append(options, kgo.ConsumePartitions(topicPartitionOffsets))
+	} else {
+		options = append(options, kgo.ConsumeTopics(topic))
 	}
 
 	client, err := kgo.NewClient(options...)
@@ -296,7 +349,14 @@ func (p *Project) StartTopicConsuming(
 		return nil, fmt.Errorf("cannot establish kafka connection: %w", err)
 	}
 
-	p.topicConsumingClient = client
+	// the client is handed over to the project only once the consuming has started
+	var isStarted bool
+
+	defer func() {
+		if !isStarted {
+			client.Close()
+		}
+	}()
 
 	adminClient := kadm.NewClient(client)
 
@@ -307,6 +367,12 @@ func (p *Project) StartTopicConsuming(
 
 	kafkaTopic := kafkaTopics[topic]
 
+	for partitionID := range consumingFilter.partitionIDs {
+		if _, ok := kafkaTopic.Partitions[partitionID]; !ok {
+			return nil, fmt.Errorf("%w: %s/%d", errPartitionNotFound, topic, partitionID)
+		}
+	}
+
 	startOffsets, err := adminClient.ListStartOffsets(ctx, topic)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list start offsets: %w", err)
@@ -325,6 +391,10 @@ func (p *Project) StartTopicConsuming(
 
 	partitionMap := make(map[int]*TopicPartition, len(kafkaTopic.Partitions))
 
+	// consumed partitions with the offsets the consuming starts from and reads up to
+	consumedStartOffsets := make(map[int32]int64, len(kafkaTopic.Partitions))
+	consumedEndOffsets := make(map[int32]int64, len(kafkaTopic.Partitions))
+
 	for _, partition := range kafkaTopic.Partitions {
 		startOffset, ok := startOffsets.Lookup(kafkaTopic.Topic, partition.Partition)
 		if !ok {
@@ -336,11 +406,13 @@ func (p *Project) StartTopicConsuming(
 			return nil, errNoEndOffsetFound
 		}
 
+		// the current offsets cover what has been consumed, nothing yet
 		outputPartition := &TopicPartition{
-			ID:               int(partition.Partition),
-			OffsetTotalStart: startOffset.Offset,
-			OffsetTotalEnd:   endOffset.Offset,
-			OffsetCurrentEnd: endOffset.Offset,
+			ID:                 int(partition.Partition),
+			OffsetTotalStart:   startOffset.Offset,
+			OffsetTotalEnd:     endOffset.Offset,
+			OffsetCurrentStart: endOffset.Offset,
+			OffsetCurrentEnd:   endOffset.Offset,
 		}
 
 		output.CountTotal += endOffset.Offset - startOffset.Offset
@@ -351,13 +423,71 @@ func (p *Project) StartTopicConsuming(
 		})
 
 		partitionMap[int(partition.Partition)] = outputPartition
+
+		if consumingFilter.partitionIDs != nil && !consumingFilter.partitionIDs[partition.Partition] {
+			continue
+		}
+
+		consumedEndOffsets[partition.Partition] = endOffset.Offset
+
+		switch consumingStrategy {
+		case TopicConsumingStrategyOffsetOldest:
+			consumedStartOffsets[partition.Partition] = startOffset.Offset
+		case TopicConsumingStrategyOffsetSpecific:
+			clampedOffset := lo.Clamp(offsetValue, startOffset.Offset, endOffset.Offset)
+			consumedStartOffsets[partition.Partition] = clampedOffset
+		}
+	}
+
+	if consumingStrategy == TopicConsumingStrategyTime {
+		timeOffsets, err := adminClient.ListOffsetsAfterMilli(ctx, timeFromMilli, topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list offsets after time: %w", err)
+		}
+
+		timeOffsets.Each(func(timeOffset kadm.ListedOffset) {
+			if timeOffset.Err == nil {
+				consumedStartOffsets[timeOffset.Partition] = timeOffset.Offset
+			}
+		})
+	}
+
+	for partitionID, startOffset := range consumedStartOffsets {
+		if outputPartition, ok := partitionMap[int(partitionID)]; ok {
+			outputPartition.OffsetCurrentStart = startOffset
+			outputPartition.OffsetCurrentEnd = startOffset
+		}
 	}
 
+	progress := newConsumingProgress(consumingFilter, consumedStartOffsets, consumedEndOffsets)
+	consumedPartitionIDs := lo.Keys(consumedEndOffsets)
+
+	decoder := &messageDecoder{
+		schemaRegistry:   p.schemaRegistry,
+		keyMessageType:   keyMessageType,
+		valueMessageType: valueMessageType,
+	}
+
+	// the goroutine keeps updating the output, the caller and the buffer get copies of it
+	outputCopy := output.copy()
+
+	buffer := newTopicMessageBuffer(p.state.ConsumingBufferSize)
+	buffer.push(nil, output.copy(), progress.TopicConsumingProgress)
+	p.topicMessageBuffer = buffer
+	p.topicConsumingClient = client
+	isStarted = true
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	p.topicConsumingCancel = cancelFunc
+
+	isConsumed := make(chan struct{})
+
+	go p.emitTopicConsuming(ctx, buffer, isConsumed)
+
 	go func() {
-		for {
-			ctx, cancelFunc := context.WithCancel(ctx)
-			p.topicConsumingCancel = cancelFunc
+		defer close(isConsumed)
 
+		for !progress.IsFinished {
 			fetches := client.PollFetches(ctx)
 
 			var isCanceled bool
@@ -381,9 +511,39 @@ func (p *Project) StartTopicConsuming(
 				break
 			}
 
+			// the topic keeps growing while it is consumed
+			fetches.EachPartition(func(fetchPartition kgo.FetchTopicPartition) {
+				outputPartition, ok := partitionMap[int(fetchPartition.Partition)]
+				if !ok || fetchPartition.HighWatermark <= outputPartition.OffsetTotalEnd {
+					return
+				}
+
+				output.CountTotal += fetchPartition.HighWatermark - outputPartition.OffsetTotalEnd
+				outputPartition.OffsetTotalEnd = fetchPartition.HighWatermark
+			})
+
 			outputMessages := make([]*TopicMessage, 0, len(fetches.Records()))
 
 			for _, message := range fetches.Records() {
+				if !progress.isConsumed(message.Partition) || !progress.scan(message) {
+					continue
+				}
+
+				if outputPartition, ok := partitionMap[int(message.Partition)]; ok {
+					outputPartition.OffsetCurrentEnd = message.Offset + 1
+				}
+
+				output.CountCurrent++
+
+				key, keySchema := decoder.decodeKey(message.Key)
+				data, dataSchema := decoder.decodeValue(message.Value)
+
+				if !consumingFilter.matches(message, key, data) {
+					continue
+				}
+
+				progress.MatchedCount++
+
 				headers := make(map[string]string)
 
 				for _, header := range message.Headers {
@@ -404,39 +564,102 @@ func (p *Project) StartTopicConsuming(
 						TimestampFormatted: message.Timestamp.Format(consumingTimeFromLayout),
 						PartitionID:        int(message.Partition),
 						Offset:             message.Offset,
-						Key:                string(message.Key),
-						Data:               string(message.Value),
+						Key:                key,
+						KeySchema:          keySchema,
+						Data:               data,
+						DataSchema:         dataSchema,
 						Headers:            string(headersJSON),
 					})
 			}
 
-			runtime.EventsEmit(
-				ctx,
-				fmt.Sprintf("kafka_message_%s", p.state.ID),
-				&TopicConsumingOutput{
-					Messages: outputMessages,
-				},
-			)
+			// finished partitions are not fetched any further, the client is closed by StopTopicConsuming
+			finishedPartitionIDs := progress.finishedPartitions(consumedPartitionIDs)
+			if len(finishedPartitionIDs) > 0 {
+				client.PauseFetchPartitions(map[string][]int32{topic: finishedPartitionIDs})
+			}
+
+			buffer.push(outputMessages, output.copy(), progress.TopicConsumingProgress)
 		}
 	}()
 
-	return output, nil
+	return outputCopy, nil
 }
 
 func (p *Project) StopTopicConsuming() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if p.topicConsumingCancel != nil {
+		p.topicConsumingCancel()
+		p.topicConsumingCancel = nil
+	}
+
+	if p.topicConsumingClient != nil {
+		p.topicConsumingClient.Close()
+		p.topicConsumingClient = nil
+	}
+
+	p.topicMessageBuffer = nil
+
+	return nil
+}
+
+// TopicMessages pages the messages buffered by the current consuming, from the newest to the oldest.
+func (p *Project) TopicMessages(beforeSequence int64, limit int) (*TopicMessagesPage, error) {
 	p.stateMutex.RLock()
 	defer p.stateMutex.RUnlock()
 
-	p.topicConsumingCancel()
-	p.topicConsumingCancel = nil
+	if p.topicMessageBuffer == nil {
+		return nil, errTopicNotConsumed
+	}
 
-	p.topicConsumingClient.Close()
-	p.topicConsumingClient = nil
+	return p.topicMessageBuffer.page(beforeSequence, limit), nil
+}
 
-	return nil
+// emitTopicConsuming emits what the consuming has buffered every topicConsumingEmitInterval, so that busy topics
+// do not flood the frontend, and once more when the consuming is over.
+func (p *Project) emitTopicConsuming(ctx context.Context, buffer *topicMessageBuffer, isConsumed <-chan struct{}) {
+	messageEventName := fmt.Sprintf("kafka_message_%s", p.state.ID)
+	progressEventName := fmt.Sprintf("kafka_consuming_progress_%s", p.state.ID)
+
+	ticker := time.NewTicker(topicConsumingEmitInterval)
+	defer ticker.Stop()
+
+	for {
+		var isOver bool
+
+		select {
+		case <-isConsumed:
+			isOver = true
+		case <-ticker.C:
+		}
+
+		// a stopped consuming has nobody to emit to anymore
+		if ctx.Err() != nil {
+			return
+		}
+
+		consumingOutput, progress := buffer.takeUnemitted(topicConsumingEmitBatchSize)
+
+		if consumingOutput != nil {
+			runtime.EventsEmit(ctx, messageEventName, consumingOutput)
+		}
+
+		if progress != nil {
+			runtime.EventsEmit(ctx, progressEventName, progress)
+		}
+
+		if isOver {
+			return
+		}
+	}
 }
 
 func (p *Project) Close() error {
+	if p.consumerGroupWatchingCancel != nil {
+		p.consumerGroupWatchingCancel()
+	}
+
 	if p.topicConsumingCancel != nil {
 		p.topicConsumingCancel()
 	}
@@ -449,9 +672,38 @@ func (p *Project) Close() error {
 		p.client.Close()
 	}
 
+	p.closeProducerClient()
+
 	return nil
 }
 
+// clientOptions returns the connection, TLS and SASL options every kafka client of the project is built with.
+func (p *Project) clientOptions() ([]kgo.Opt, error) {
+	netDialer := &net.Dialer{Timeout: kafkaConnectionTimeout}
+	dialFunc := netDialer.DialContext
+
+	if p.state.isTLS() {
+		tlsConfig, err := p.state.TLS.Config()
+		if err != nil {
+			return nil, err
+		}
+
+		tlsDialer := &tls.Dialer{NetDialer: netDialer, Config: tlsConfig}
+		dialFunc = tlsDialer.DialContext
+	}
+
+	options := []kgo.Opt{
+		kgo.SeedBrokers(p.state.Address),
+		kgo.Dialer(dialFunc),
+	}
+
+	if p.state.isSASL() {
+		options = append(options, kgo.SASL(p.state.saslMechanism()))
+	}
+
+	return options, nil
+}
+
 func (p *Project) saveState() error {
 	copiedState := *p.state
 	copiedState.IsConnected = false
@@ -0,0 +1,125 @@
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+
+	"github.com/catake-com/multibase/backend/state"
+)
+
+func TestProjectSaveStateKeepsProtoMessageTypes(t *testing.T) {
+	project := newTestProject(t)
+
+	protoDir := t.TempDir()
+	protoFilePath := filepath.Join(protoDir, "users.proto")
+
+	err := os.WriteFile(protoFilePath, []byte(`syntax = "proto3";
+package users;
+
+message User {
+  string name = 1;
+}
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = project.OpenProtoFile(protoFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = project.SaveTopicProtoMapping(&TopicProtoMapping{Topic: "users", ValueMessageType: "users.User"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messageTypes := project.protoMessageTypes
+
+	// the proto file is gone, saving unrelated settings neither parses it again nor fails
+	err = os.Remove(protoFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newState := *project.state
+	newState.Address = "127.0.0.1:9093"
+
+	err = project.SaveState(&newState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if project.protoMessageTypes != messageTypes || project.state.Address != "127.0.0.1:9093" {
+		t.Fatal("expected the state to be saved with the loaded message types")
+	}
+
+	changedState := *project.state
+	changedState.ProtoImportPathList = []string{protoDir, t.TempDir()}
+
+	err = project.SaveState(&changedState)
+	if err == nil {
+		t.Fatal("expected changed proto settings to be parsed again")
+	}
+}
+
+func TestProjectSaveStateMapsAuthMethod(t *testing.T) {
+	project := newTestProject(t)
+
+	newState := *project.state
+	newState.AuthMethod = AuthMethodSASLSSL
+	newState.AuthUsername = "user"
+	newState.AuthPassword = "password"
+
+	err := project.SaveState(&newState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	securityProtocol, saslMechanism := project.state.SecurityProtocol, project.state.SASLMechanism
+	if securityProtocol != SecurityProtocolSASLSSL || saslMechanism != SASLMechanismPlain {
+		t.Fatalf("unexpected security settings: %s %s", securityProtocol, saslMechanism)
+	}
+
+	if project.state.AuthUsername != "user" || project.state.AuthPassword != "password" {
+		t.Fatal("expected the credentials to be kept")
+	}
+
+	plaintextState := *project.state
+	plaintextState.AuthMethod = AuthMethodPlaintext
+
+	err = project.SaveState(&plaintextState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if project.state.SecurityProtocol != SecurityProtocolPlaintext || project.state.AuthUsername != "" {
+		t.Fatal("expected plaintext auth to drop the credentials")
+	}
+}
+
+func newTestProject(t *testing.T) *Project {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+
+	stateStorage, err := state.NewStorage(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = stateStorage.Close()
+	})
+
+	project, err := NewProject("test", stateStorage, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return project
+}
@@ -0,0 +1,342 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	errProtoMessageTypeNotFound = errors.New("proto message type not found")
+	errEmptyTopicProtoMapping   = errors.New("empty topic proto mapping")
+)
+
+// protoMessageTypes indexes the message types of the local proto files and descriptor sets by their full names.
+type protoMessageTypes struct {
+	messagesByName map[string]*desc.MessageDescriptor
+}
+
+func newProtoMessageTypes(
+	importPathList,
+	protoFileList,
+	descriptorSetList []string,
+) (*protoMessageTypes, error) {
+	if len(protoFileList) == 0 && len(descriptorSetList) == 0 {
+		return nil, nil
+	}
+
+	messageTypes := &protoMessageTypes{
+		messagesByName: make(map[string]*desc.MessageDescriptor),
+	}
+
+	if len(protoFileList) > 0 {
+		resolvedFileList, err := protoparse.ResolveFilenames(importPathList, protoFileList...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve proto file names: %w", err)
+		}
+
+		parser := protoparse.Parser{
+			ImportPaths:      importPathList,
+			InferImportPaths: len(importPathList) == 0,
+		}
+
+		fileDescriptors, err := parser.ParseFiles(resolvedFileList...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proto files: %w", err)
+		}
+
+		for _, fileDescriptor := range fileDescriptors {
+			messageTypes.addFile(fileDescriptor)
+		}
+	}
+
+	for _, descriptorSetPath := range descriptorSetList {
+		fileDescriptors, err := readDescriptorSet(descriptorSetPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fileDescriptor := range fileDescriptors {
+			messageTypes.addFile(fileDescriptor)
+		}
+	}
+
+	return messageTypes, nil
+}
+
+// names returns the sorted full names of every known message type, nil receiver included.
+func (t *protoMessageTypes) names() []string {
+	if t == nil {
+		return []string{}
+	}
+
+	names := lo.Keys(t.messagesByName)
+	sort.Strings(names)
+
+	return names
+}
+
+// message returns nil for an empty name, so that an unmapped key or value stays undecoded.
+func (t *protoMessageTypes) message(name string) (*desc.MessageDescriptor, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	if t == nil {
+		return nil, fmt.Errorf("%w: %s", errProtoMessageTypeNotFound, name)
+	}
+
+	messageDescriptor, ok := t.messagesByName[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errProtoMessageTypeNotFound, name)
+	}
+
+	return messageDescriptor, nil
+}
+
+// mapping returns the message types the topic is mapped to, nil ones when it is not mapped.
+func (t *protoMessageTypes) mapping(
+	mappings []*TopicProtoMapping,
+	topic string,
+) (*desc.MessageDescriptor, *desc.MessageDescriptor, error) {
+	mapping, ok := lo.Find(mappings, func(mapping *TopicProtoMapping) bool {
+		return mapping.Topic == topic
+	})
+	if !ok {
+		return nil, nil, nil
+	}
+
+	keyMessageType, err := t.message(mapping.KeyMessageType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	valueMessageType, err := t.message(mapping.ValueMessageType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return keyMessageType, valueMessageType, nil
+}
+
+func (t *protoMessageTypes) validateMappings(mappings []*TopicProtoMapping) error {
+	for _, mapping := range mappings {
+		if mapping.Topic == "" {
+			return errEmptyTopicName
+		}
+
+		if mapping.KeyMessageType == "" && mapping.ValueMessageType == "" {
+			return fmt.Errorf("%w: %s", errEmptyTopicProtoMapping, mapping.Topic)
+		}
+
+		_, _, err := t.mapping([]*TopicProtoMapping{mapping}, mapping.Topic)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *protoMessageTypes) addFile(fileDescriptor *desc.FileDescriptor) {
+	for _, dependency := range fileDescriptor.GetDependencies() {
+		t.addFile(dependency)
+	}
+
+	for _, messageDescriptor := range fileDescriptor.GetMessageTypes() {
+		t.addMessage(messageDescriptor)
+	}
+}
+
+func (t *protoMessageTypes) addMessage(messageDescriptor *desc.MessageDescriptor) {
+	// map entries are synthetic types that never travel on their own
+	if messageDescriptor.IsMapEntry() {
+		return
+	}
+
+	t.messagesByName[messageDescriptor.GetFullyQualifiedName()] = messageDescriptor
+
+	for _, nestedMessageDescriptor := range messageDescriptor.GetNestedMessageTypes() {
+		t.addMessage(nestedMessageDescriptor)
+	}
+}
+
+// hasProtoSources tells whether the other state loads message types from the same proto sources.
+func (s *State) hasProtoSources(other *State) bool {
+	return isEqualStrings(s.ProtoImportPathList, other.ProtoImportPathList) &&
+		isEqualStrings(s.ProtoFileList, other.ProtoFileList) &&
+		isEqualStrings(s.ProtoDescriptorSetList, other.ProtoDescriptorSetList)
+}
+
+func (s *State) hasTopicProtoMappings(other *State) bool {
+	if len(s.TopicProtoMappings) != len(other.TopicProtoMappings) {
+		return false
+	}
+
+	for i, mapping := range s.TopicProtoMappings {
+		if *mapping != *other.TopicProtoMappings[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isEqualStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func readDescriptorSet(descriptorSetPath string) ([]*desc.FileDescriptor, error) {
+	data, err := os.ReadFile(descriptorSetPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+
+	descriptorSet := &descriptorpb.FileDescriptorSet{}
+
+	err = proto.Unmarshal(data, descriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set %s: %w", filepath.Base(descriptorSetPath), err)
+	}
+
+	fileDescriptors, err := desc.CreateFileDescriptorsFromSet(descriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set %s: %w", filepath.Base(descriptorSetPath), err)
+	}
+
+	return lo.Values(fileDescriptors), nil
+}
+
+func (p *Project) ProtoMessageTypes() []string {
+	p.stateMutex.RLock()
+	defer p.stateMutex.RUnlock()
+
+	return p.protoMessageTypes.names()
+}
+
+func (p *Project) OpenProtoFile(protoFilePath string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if lo.Contains(p.state.ProtoFileList, protoFilePath) {
+		return nil
+	}
+
+	importPathList := p.state.ProtoImportPathList
+	if len(importPathList) == 0 {
+		importPathList = []string{filepath.Dir(protoFilePath)}
+	}
+
+	protoFileList := append([]string{protoFilePath}, p.state.ProtoFileList...)
+
+	return p.refreshProtoMessageTypes(importPathList, protoFileList, p.state.ProtoDescriptorSetList)
+}
+
+func (p *Project) OpenProtoImportPath(importPath string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if lo.Contains(p.state.ProtoImportPathList, importPath) {
+		return nil
+	}
+
+	importPathList := append(p.state.ProtoImportPathList, importPath)
+
+	return p.refreshProtoMessageTypes(importPathList, p.state.ProtoFileList, p.state.ProtoDescriptorSetList)
+}
+
+func (p *Project) RemoveProtoImportPath(importPath string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	importPathList := lo.Without(p.state.ProtoImportPathList, importPath)
+
+	return p.refreshProtoMessageTypes(importPathList, p.state.ProtoFileList, p.state.ProtoDescriptorSetList)
+}
+
+func (p *Project) OpenProtoDescriptorSet(descriptorSetPath string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if lo.Contains(p.state.ProtoDescriptorSetList, descriptorSetPath) {
+		return nil
+	}
+
+	descriptorSetList := append(p.state.ProtoDescriptorSetList, descriptorSetPath)
+
+	return p.refreshProtoMessageTypes(p.state.ProtoImportPathList, p.state.ProtoFileList, descriptorSetList)
+}
+
+// DeleteAllProtoFiles drops the topic proto mappings as well, since no message type is left for them.
+func (p *Project) DeleteAllProtoFiles() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	p.state.ProtoFileList = nil
+	p.state.ProtoDescriptorSetList = nil
+	p.state.TopicProtoMappings = nil
+	p.protoMessageTypes = nil
+
+	return p.saveState()
+}
+
+// SaveTopicProtoMapping replaces the mapping of the topic, a mapping without message types removes it.
+func (p *Project) SaveTopicProtoMapping(mapping *TopicProtoMapping) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	mappings := lo.Reject(p.state.TopicProtoMappings, func(existingMapping *TopicProtoMapping, _ int) bool {
+		return existingMapping.Topic == mapping.Topic
+	})
+
+	if mapping.KeyMessageType != "" || mapping.ValueMessageType != "" {
+		mappings = append(mappings, mapping)
+	}
+
+	err := p.protoMessageTypes.validateMappings(mappings)
+	if err != nil {
+		return err
+	}
+
+	p.state.TopicProtoMappings = mappings
+
+	return p.saveState()
+}
+
+// refreshProtoMessageTypes keeps the previous proto settings when the new ones cannot be loaded.
+func (p *Project) refreshProtoMessageTypes(importPathList, protoFileList, descriptorSetList []string) error {
+	messageTypes, err := newProtoMessageTypes(importPathList, protoFileList, descriptorSetList)
+	if err != nil {
+		return err
+	}
+
+	err = messageTypes.validateMappings(p.state.TopicProtoMappings)
+	if err != nil {
+		return err
+	}
+
+	p.state.ProtoImportPathList = importPathList
+	p.state.ProtoFileList = protoFileList
+	p.state.ProtoDescriptorSetList = descriptorSetList
+	p.protoMessageTypes = messageTypes
+
+	return p.saveState()
+}
@@ -0,0 +1,499 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/linkedin/goavro/v2"
+)
+
+const (
+	schemaRegistryTimeout     = 10 * time.Second
+	schemaRegistryRetryDelay  = time.Minute
+	schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+	// schemaRegistryMagicByte starts every payload in the confluent wire format, followed by a 4 bytes schema id.
+	schemaRegistryMagicByte  = 0
+	schemaRegistryHeaderSize = 5
+)
+
+var (
+	errInvalidSchemaRegistryURL     = errors.New("invalid schema registry url")
+	errSchemaRegistryNotConfigured  = errors.New("schema registry is not configured")
+	errSchemaRegistryRequest        = errors.New("schema registry request failed")
+	errUnknownSchemaType            = errors.New("unknown schema type")
+	errInvalidProtobufMessageIndex  = errors.New("invalid protobuf message index")
+	errProtobufSchemaWithoutMessage = errors.New("protobuf schema has no messages")
+	errInvalidJSONPayload           = errors.New("invalid json payload")
+)
+
+type registrySchemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type registrySchema struct {
+	ID         int                        `json:"id"`
+	Subject    string                     `json:"subject"`
+	Version    int                        `json:"version"`
+	SchemaType SchemaType                 `json:"schemaType"`
+	Schema     string                     `json:"schema"`
+	References []*registrySchemaReference `json:"references"`
+
+	avroCodec *goavro.Codec
+	protoFile *desc.FileDescriptor
+	// err and failedAt keep a schema that could not be loaded from being requested for every message
+	err      error
+	failedAt time.Time
+}
+
+type registrySchemaVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// schemaRegistryClient talks to a confluent compatible schema registry and caches the schemas it has compiled.
+type schemaRegistryClient struct {
+	settings    SchemaRegistry
+	httpClient  *http.Client
+	schemasByID map[int]*registrySchema
+	mutex       sync.Mutex
+}
+
+func newSchemaRegistryClient(settings *SchemaRegistry) *schemaRegistryClient {
+	if settings == nil || settings.URL == "" {
+		return nil
+	}
+
+	return &schemaRegistryClient{
+		settings:    *settings,
+		httpClient:  &http.Client{Timeout: schemaRegistryTimeout},
+		schemasByID: make(map[int]*registrySchema),
+	}
+}
+
+func (r *SchemaRegistry) validate() error {
+	if r == nil || r.URL == "" {
+		return nil
+	}
+
+	registryURL, err := url.Parse(r.URL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSchemaRegistryURL, err)
+	}
+
+	if (registryURL.Scheme != "http" && registryURL.Scheme != "https") || registryURL.Host == "" {
+		return fmt.Errorf("%w: %s", errInvalidSchemaRegistryURL, r.URL)
+	}
+
+	return nil
+}
+
+// decode turns a confluent wire format payload into JSON, payloads in any other format come back with a nil schema.
+func (c *schemaRegistryClient) decode(data []byte) (string, *MessageSchema, error) {
+	if len(data) < schemaRegistryHeaderSize || data[0] != schemaRegistryMagicByte {
+		return "", nil, nil
+	}
+
+	schemaID := int(binary.BigEndian.Uint32(data[1:schemaRegistryHeaderSize]))
+	payload := data[schemaRegistryHeaderSize:]
+
+	messageSchema := &MessageSchema{ID: schemaID}
+
+	schema, err := c.schemaByID(schemaID)
+	if err != nil {
+		return "", messageSchema, err
+	}
+
+	messageSchema.Type = schema.SchemaType
+	messageSchema.Subject = schema.Subject
+	messageSchema.Version = schema.Version
+
+	var text string
+
+	switch schema.SchemaType {
+	case SchemaTypeAvro:
+		text, err = decodeAvro(schema.avroCodec, payload)
+	case SchemaTypeProtobuf:
+		text, err = decodeRegistryProtobuf(schema.protoFile, payload)
+	case SchemaTypeJSON:
+		text, err = decodeJSONSchemaPayload(payload)
+	}
+
+	if err != nil {
+		return "", messageSchema, err
+	}
+
+	return text, messageSchema, nil
+}
+
+// encode serializes the JSON text with the latest schema of the subject into the confluent wire format.
+func (c *schemaRegistryClient) encode(subject, text string) ([]byte, error) {
+	schema, err := c.latestSchema(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, schemaRegistryHeaderSize)
+	data[0] = schemaRegistryMagicByte
+	binary.BigEndian.PutUint32(data[1:], uint32(schema.ID))
+
+	var payload []byte
+
+	switch schema.SchemaType {
+	case SchemaTypeAvro:
+		payload, err = encodeAvro(schema.avroCodec, text)
+	case SchemaTypeProtobuf:
+		payload, err = encodeRegistryProtobuf(schema.protoFile, text)
+	case SchemaTypeJSON:
+		payload, err = encodeJSONSchemaPayload(text)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode with %s version %d: %w", subject, schema.Version, err)
+	}
+
+	return append(data, payload...), nil
+}
+
+func (c *schemaRegistryClient) schemaByID(schemaID int) (*registrySchema, error) {
+	c.mutex.Lock()
+	schema, ok := c.schemasByID[schemaID]
+	c.mutex.Unlock()
+
+	if ok && schema.err == nil {
+		return schema, nil
+	}
+
+	if ok && time.Since(schema.failedAt) < schemaRegistryRetryDelay {
+		return nil, schema.err
+	}
+
+	schema, err := c.loadSchema(schemaID)
+	if err != nil {
+		schema = &registrySchema{ID: schemaID, err: err, failedAt: time.Now()}
+	}
+
+	c.mutex.Lock()
+	c.schemasByID[schemaID] = schema
+	c.mutex.Unlock()
+
+	return schema, err
+}
+
+func (c *schemaRegistryClient) loadSchema(schemaID int) (*registrySchema, error) {
+	schema := &registrySchema{}
+
+	err := c.get(fmt.Sprintf("/schemas/ids/%d", schemaID), schema)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.ID = schemaID
+
+	// subject and version are only informative, older registries do not expose them by id
+	var versions []*registrySchemaVersion
+	if err := c.get(fmt.Sprintf("/schemas/ids/%d/versions", schemaID), &versions); err == nil && len(versions) > 0 {
+		schema.Subject = versions[0].Subject
+		schema.Version = versions[0].Version
+	}
+
+	err = c.compile(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+func (c *schemaRegistryClient) latestSchema(subject string) (*registrySchema, error) {
+	schema, err := c.subjectVersion(subject, "latest")
+	if err != nil {
+		return nil, err
+	}
+
+	cachedSchema, err := c.schemaByID(schema.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedSchema, nil
+}
+
+func (c *schemaRegistryClient) subjectVersion(subject, version string) (*registrySchema, error) {
+	schema := &registrySchema{}
+
+	err := c.get(fmt.Sprintf("/subjects/%s/versions/%s", url.PathEscape(subject), version), schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+func (c *schemaRegistryClient) compile(schema *registrySchema) error {
+	// the registry omits the type of avro schemas
+	if schema.SchemaType == "" {
+		schema.SchemaType = SchemaTypeAvro
+	}
+
+	switch schema.SchemaType {
+	case SchemaTypeAvro:
+		codec, err := goavro.NewCodec(schema.Schema)
+		if err != nil {
+			return fmt.Errorf("failed to parse avro schema %d: %w", schema.ID, err)
+		}
+
+		schema.avroCodec = codec
+	case SchemaTypeProtobuf:
+		fileName := fmt.Sprintf("schema_%d.proto", schema.ID)
+		files := map[string]string{fileName: schema.Schema}
+
+		err := c.collectReferences(schema.References, files)
+		if err != nil {
+			return err
+		}
+
+		parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(files)}
+
+		fileDescriptors, err := parser.ParseFiles(fileName)
+		if err != nil {
+			return fmt.Errorf("failed to parse protobuf schema %d: %w", schema.ID, err)
+		}
+
+		schema.protoFile = fileDescriptors[0]
+	case SchemaTypeJSON:
+	default:
+		return fmt.Errorf("%w: %s", errUnknownSchemaType, schema.SchemaType)
+	}
+
+	return nil
+}
+
+// collectReferences fetches the schemas imported by a protobuf schema, keyed by their import names.
+func (c *schemaRegistryClient) collectReferences(references []*registrySchemaReference, files map[string]string) error {
+	for _, reference := range references {
+		if _, ok := files[reference.Name]; ok {
+			continue
+		}
+
+		schema, err := c.subjectVersion(reference.Subject, fmt.Sprint(reference.Version))
+		if err != nil {
+			return err
+		}
+
+		files[reference.Name] = schema.Schema
+
+		err = c.collectReferences(schema.References, files)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *schemaRegistryClient) get(path string, result interface{}) error {
+	request, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(c.settings.URL, "/")+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build a schema registry request: %w", err)
+	}
+
+	request.Header.Set("Accept", schemaRegistryContentType)
+
+	if c.settings.Username != "" {
+		request.SetBasicAuth(c.settings.Username, c.settings.Password)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errSchemaRegistryRequest, err)
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errSchemaRegistryRequest, err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"%w: %s %s: %s",
+			errSchemaRegistryRequest,
+			path,
+			response.Status,
+			bytes.TrimSpace(body),
+		)
+	}
+
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return fmt.Errorf("failed to parse schema registry response: %w", err)
+	}
+
+	return nil
+}
+
+func decodeAvro(codec *goavro.Codec, payload []byte) (string, error) {
+	native, _, err := codec.NativeFromBinary(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode avro: %w", err)
+	}
+
+	text, err := codec.TextualFromNative(nil, native)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode avro: %w", err)
+	}
+
+	return string(text), nil
+}
+
+func encodeAvro(codec *goavro.Codec, text string) ([]byte, error) {
+	native, _, err := codec.NativeFromTextual([]byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode avro: %w", err)
+	}
+
+	payload, err := codec.BinaryFromNative(nil, native)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode avro: %w", err)
+	}
+
+	return payload, nil
+}
+
+// decodeRegistryProtobuf reads the message indexes that point at the message type within the schema
+// and then the message itself.
+func decodeRegistryProtobuf(file *desc.FileDescriptor, payload []byte) (string, error) {
+	count, n := binary.Varint(payload)
+	if n <= 0 || count < 0 || count > int64(len(payload)) {
+		return "", errInvalidProtobufMessageIndex
+	}
+
+	payload = payload[n:]
+
+	// a zero count is the shortcut for the first message of the schema
+	indexes := []int64{0}
+
+	if count > 0 {
+		indexes = make([]int64, 0, count)
+
+		for i := int64(0); i < count; i++ {
+			index, n := binary.Varint(payload)
+			if n <= 0 {
+				return "", errInvalidProtobufMessageIndex
+			}
+
+			indexes = append(indexes, index)
+			payload = payload[n:]
+		}
+	}
+
+	messageDescriptor, err := protobufMessageByIndexes(file, indexes)
+	if err != nil {
+		return "", err
+	}
+
+	return decodeProtobuf(messageDescriptor, payload)
+}
+
+func encodeRegistryProtobuf(file *desc.FileDescriptor, text string) ([]byte, error) {
+	messageDescriptor, err := protobufMessageByIndexes(file, []int64{0})
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := encodeProtobuf(messageDescriptor, text)
+	if err != nil {
+		return nil, err
+	}
+
+	// message indexes of the first message in the schema
+	return append([]byte{0}, payload...), nil
+}
+
+func protobufMessageByIndexes(file *desc.FileDescriptor, indexes []int64) (*desc.MessageDescriptor, error) {
+	messageDescriptors := file.GetMessageTypes()
+	if len(messageDescriptors) == 0 {
+		return nil, errProtobufSchemaWithoutMessage
+	}
+
+	var messageDescriptor *desc.MessageDescriptor
+
+	for _, index := range indexes {
+		if index < 0 || index >= int64(len(messageDescriptors)) {
+			return nil, fmt.Errorf("%w: %v", errInvalidProtobufMessageIndex, indexes)
+		}
+
+		messageDescriptor = messageDescriptors[index]
+		messageDescriptors = messageDescriptor.GetNestedMessageTypes()
+	}
+
+	return messageDescriptor, nil
+}
+
+func decodeProtobuf(messageDescriptor *desc.MessageDescriptor, payload []byte) (string, error) {
+	message := dynamic.NewMessage(messageDescriptor)
+
+	err := message.Unmarshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode protobuf: %w", err)
+	}
+
+	text, err := message.MarshalJSONPB(&jsonpb.Marshaler{OrigName: true})
+	if err != nil {
+		return "", fmt.Errorf("failed to decode protobuf: %w", err)
+	}
+
+	return string(text), nil
+}
+
+func encodeProtobuf(messageDescriptor *desc.MessageDescriptor, text string) ([]byte, error) {
+	message := dynamic.NewMessage(messageDescriptor)
+
+	err := message.UnmarshalJSONPB(&jsonpb.Unmarshaler{}, []byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode protobuf: %w", err)
+	}
+
+	payload, err := message.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode protobuf: %w", err)
+	}
+
+	return payload, nil
+}
+
+func decodeJSONSchemaPayload(payload []byte) (string, error) {
+	if !json.Valid(payload) {
+		return "", errInvalidJSONPayload
+	}
+
+	return string(payload), nil
+}
+
+func encodeJSONSchemaPayload(text string) ([]byte, error) {
+	payload := &bytes.Buffer{}
+
+	err := json.Compact(payload, []byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidJSONPayload, err)
+	}
+
+	return payload.Bytes(), nil
+}
@@ -0,0 +1,463 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testAvroSchema = `{
+  "type": "record",
+  "name": "User",
+  "fields": [
+    {"name": "name", "type": "string"},
+    {"name": "age", "type": "int"}
+  ]
+}`
+	testProtobufSchema = `syntax = "proto3";
+package users;
+
+import "address.proto";
+
+message User {
+  string name = 1;
+  int32 age = 2;
+  Address address = 3;
+}
+`
+	testProtobufReferenceSchema = `syntax = "proto3";
+package users;
+
+message Address {
+  string city = 1;
+}
+`
+	testJSONSchema = `{"type": "object", "properties": {"name": {"type": "string"}}}`
+)
+
+// testSchemaRegistry is an in-process confluent compatible registry serving schemas by id and subject versions.
+type testSchemaRegistry struct {
+	schemasByID    map[int]*registrySchema
+	schemaRequests map[string]int
+	requestsMutex  sync.Mutex
+	username       string
+	password       string
+	isVersionsByID bool
+}
+
+func newTestSchemaRegistry(t *testing.T, registry *testSchemaRegistry) *schemaRegistryClient {
+	t.Helper()
+
+	registry.schemaRequests = make(map[string]int)
+
+	server := httptest.NewServer(registry)
+	t.Cleanup(server.Close)
+
+	return newSchemaRegistryClient(&SchemaRegistry{
+		URL:      server.URL + "/",
+		Username: registry.username,
+		Password: registry.password,
+	})
+}
+
+func (r *testSchemaRegistry) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	r.requestsMutex.Lock()
+	r.schemaRequests[request.URL.Path]++
+	r.requestsMutex.Unlock()
+
+	if r.username != "" {
+		username, password, ok := request.BasicAuth()
+		if !ok || username != r.username || password != r.password {
+			writeTestRegistryError(writer, http.StatusUnauthorized, 40101, "Unauthorized")
+
+			return
+		}
+	}
+
+	var (
+		schemaID         int
+		subject, version string
+	)
+
+	switch {
+	case strings.HasSuffix(request.URL.Path, "/versions") &&
+		r.parsePath(request.URL.Path, "/schemas/ids/%d/versions", &schemaID):
+		schema, ok := r.schemasByID[schemaID]
+		if !ok || !r.isVersionsByID {
+			writeTestRegistryError(writer, http.StatusNotFound, 40403, "Schema not found")
+
+			return
+		}
+
+		schemaVersion := &registrySchemaVersion{Subject: schema.Subject, Version: schema.Version}
+		writeTestRegistryJSON(writer, []*registrySchemaVersion{schemaVersion})
+	case r.parsePath(request.URL.Path, "/schemas/ids/%d", &schemaID):
+		schema, ok := r.schemasByID[schemaID]
+		if !ok {
+			writeTestRegistryError(writer, http.StatusNotFound, 40403, "Schema not found")
+
+			return
+		}
+
+		writeTestRegistryJSON(writer, &registrySchema{
+			SchemaType: schema.SchemaType,
+			Schema:     schema.Schema,
+			References: schema.References,
+		})
+	case r.parsePath(request.URL.Path, "/subjects/%s", &subject):
+		subject, version, _ = strings.Cut(subject, "/versions/")
+
+		schema := r.subjectSchema(subject, version)
+		if schema == nil {
+			writeTestRegistryError(writer, http.StatusNotFound, 40401, "Subject not found")
+
+			return
+		}
+
+		writeTestRegistryJSON(writer, schema)
+	default:
+		writeTestRegistryError(writer, http.StatusNotFound, 404, "Not found")
+	}
+}
+
+func (r *testSchemaRegistry) parsePath(path, format string, values ...interface{}) bool {
+	_, err := fmt.Sscanf(path, format, values...)
+
+	return err == nil
+}
+
+// subjectSchema returns the requested version of the subject, latest is the highest one.
+func (r *testSchemaRegistry) subjectSchema(subject, version string) *registrySchema {
+	var result *registrySchema
+
+	for schemaID, schema := range r.schemasByID {
+		if schema.Subject != subject {
+			continue
+		}
+
+		if version != "latest" && version != fmt.Sprint(schema.Version) {
+			continue
+		}
+
+		if result == nil || schema.Version > result.Version {
+			result = &registrySchema{
+				ID:         schemaID,
+				Subject:    schema.Subject,
+				Version:    schema.Version,
+				SchemaType: schema.SchemaType,
+				Schema:     schema.Schema,
+				References: schema.References,
+			}
+		}
+	}
+
+	return result
+}
+
+func (r *testSchemaRegistry) requests(path string) int {
+	r.requestsMutex.Lock()
+	defer r.requestsMutex.Unlock()
+
+	return r.schemaRequests[path]
+}
+
+func writeTestRegistryJSON(writer http.ResponseWriter, body interface{}) {
+	writer.Header().Set("Content-Type", schemaRegistryContentType)
+
+	_ = json.NewEncoder(writer).Encode(body)
+}
+
+func writeTestRegistryError(writer http.ResponseWriter, statusCode, errorCode int, message string) {
+	writer.Header().Set("Content-Type", schemaRegistryContentType)
+	writer.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(writer).Encode(map[string]interface{}{"error_code": errorCode, "message": message})
+}
+
+func TestSchemaRegistryEncodeDecode(t *testing.T) {
+	client := newTestSchemaRegistry(t, &testSchemaRegistry{
+		isVersionsByID: true,
+		schemasByID: map[int]*registrySchema{
+			1: {Subject: "users-avro-value", Version: 1, Schema: `"string"`},
+			2: {Subject: "users-avro-value", Version: 2, Schema: testAvroSchema},
+			3: {
+				Subject:    "users-proto-value",
+				Version:    1,
+				SchemaType: SchemaTypeProtobuf,
+				Schema:     testProtobufSchema,
+				References: []*registrySchemaReference{
+					{Name: "address.proto", Subject: "address", Version: 1},
+				},
+			},
+			4: {
+				Subject:    "address",
+				Version:    1,
+				SchemaType: SchemaTypeProtobuf,
+				Schema:     testProtobufReferenceSchema,
+			},
+			5: {
+				Subject:    "users-json-value",
+				Version:    1,
+				SchemaType: SchemaTypeJSON,
+				Schema:     testJSONSchema,
+			},
+		},
+	})
+
+	testCases := []struct {
+		subject    string
+		text       string
+		schemaID   int
+		schemaType SchemaType
+		version    int
+	}{
+		{
+			subject:    "users-avro-value",
+			text:       `{"name":"multibase","age":3}`,
+			schemaID:   2,
+			schemaType: SchemaTypeAvro,
+			version:    2,
+		},
+		{
+			subject:    "users-proto-value",
+			text:       `{"name":"multibase","age":3,"address":{"city":"Lisbon"}}`,
+			schemaID:   3,
+			schemaType: SchemaTypeProtobuf,
+			version:    1,
+		},
+		{
+			subject:    "users-json-value",
+			text:       `{"name":"multibase"}`,
+			schemaID:   5,
+			schemaType: SchemaTypeJSON,
+			version:    1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(string(testCase.schemaType), func(t *testing.T) {
+			data, err := client.encode(testCase.subject, testCase.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if data[0] != schemaRegistryMagicByte {
+				t.Fatalf("unexpected magic byte %d", data[0])
+			}
+
+			schemaID := binary.BigEndian.Uint32(data[1:schemaRegistryHeaderSize])
+			if int(schemaID) != testCase.schemaID {
+				t.Fatalf("expected schema id %d, got %d", testCase.schemaID, schemaID)
+			}
+
+			text, messageSchema, err := client.decode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertTestJSONEqual(t, testCase.text, text)
+
+			expectedSchema := &MessageSchema{
+				ID:      testCase.schemaID,
+				Type:    testCase.schemaType,
+				Subject: testCase.subject,
+				Version: testCase.version,
+			}
+			if *messageSchema != *expectedSchema {
+				t.Fatalf("expected schema %+v, got %+v", expectedSchema, messageSchema)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistryDecodeProtobufMessageIndexes(t *testing.T) {
+	client := newTestSchemaRegistry(t, &testSchemaRegistry{
+		schemasByID: map[int]*registrySchema{
+			7: {SchemaType: SchemaTypeProtobuf, Schema: `syntax = "proto3";
+
+message Other {
+  string id = 1;
+}
+
+message Outer {
+  message Inner {
+    string name = 1;
+  }
+}
+`},
+		},
+	})
+
+	schema, err := client.schemaByID(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	innerMessageType := schema.protoFile.GetMessageTypes()[1].GetNestedMessageTypes()[0]
+
+	innerPayload, err := encodeProtobuf(innerMessageType, `{"name":"inner"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// two indexes, zigzag encoded: the second message of the schema, then its first nested message
+	data := append([]byte{schemaRegistryMagicByte, 0, 0, 0, 7, 4, 2, 0}, innerPayload...)
+
+	text, messageSchema, err := client.decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTestJSONEqual(t, `{"name":"inner"}`, text)
+
+	// the registry does not expose versions by id here, so the schema is known by its id only
+	if messageSchema.ID != 7 || messageSchema.Subject != "" || messageSchema.Type != SchemaTypeProtobuf {
+		t.Fatalf("unexpected schema %+v", messageSchema)
+	}
+
+	_, _, err = client.decode([]byte{schemaRegistryMagicByte, 0, 0, 0, 7, 2, 6})
+	if !errors.Is(err, errInvalidProtobufMessageIndex) {
+		t.Fatalf("expected an invalid message index error, got %v", err)
+	}
+}
+
+func TestSchemaRegistryLookup(t *testing.T) {
+	registry := &testSchemaRegistry{
+		username:       "user",
+		password:       "secret",
+		isVersionsByID: true,
+		schemasByID: map[int]*registrySchema{
+			10: {Subject: "orders-value", Version: 1, Schema: `"string"`},
+			11: {Subject: "orders-value", Version: 2, Schema: `"long"`},
+		},
+	}
+	client := newTestSchemaRegistry(t, registry)
+
+	schema, err := client.subjectVersion("orders-value", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema.ID != 10 || schema.Version != 1 {
+		t.Fatalf("unexpected subject version %+v", schema)
+	}
+
+	schema, err = client.latestSchema("orders-value")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema.ID != 11 || schema.Subject != "orders-value" || schema.Version != 2 || schema.avroCodec == nil {
+		t.Fatalf("unexpected latest schema %+v", schema)
+	}
+
+	// schemas are compiled once and then served from the cache
+	_, err = client.schemaByID(11)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests := registry.requests("/schemas/ids/11"); requests != 1 {
+		t.Fatalf("expected the schema to be requested once, got %d", requests)
+	}
+}
+
+func TestSchemaRegistryErrors(t *testing.T) {
+	registry := &testSchemaRegistry{
+		schemasByID: map[int]*registrySchema{
+			20: {Subject: "broken-value", Version: 1, Schema: `{"type": "unknown"}`},
+			21: {Subject: "xml-value", Version: 1, SchemaType: "XML", Schema: `<schema/>`},
+			22: {Subject: "json-value", Version: 1, SchemaType: SchemaTypeJSON, Schema: testJSONSchema},
+		},
+	}
+	client := newTestSchemaRegistry(t, registry)
+
+	_, err := client.encode("missing-value", `{}`)
+	if !errors.Is(err, errSchemaRegistryRequest) || !strings.Contains(err.Error(), "Subject not found") {
+		t.Fatalf("expected a registry error with the response message, got %v", err)
+	}
+
+	_, _, err = client.decode([]byte{schemaRegistryMagicByte, 0, 0, 0, 99, 1})
+	if !errors.Is(err, errSchemaRegistryRequest) {
+		t.Fatalf("expected a registry error, got %v", err)
+	}
+
+	_, err = client.schemaByID(20)
+	if err == nil || !strings.Contains(err.Error(), "failed to parse avro schema 20") {
+		t.Fatalf("expected an avro schema error, got %v", err)
+	}
+
+	_, err = client.schemaByID(21)
+	if !errors.Is(err, errUnknownSchemaType) {
+		t.Fatalf("expected an unknown schema type error, got %v", err)
+	}
+
+	// schemas that failed to load are not requested again until the retry delay passes
+	_, _ = client.schemaByID(21)
+
+	if requests := registry.requests("/schemas/ids/21"); requests != 1 {
+		t.Fatalf("expected the failed schema to be requested once, got %d", requests)
+	}
+
+	_, err = client.encode("json-value", `{"name":`)
+	if !errors.Is(err, errInvalidJSONPayload) {
+		t.Fatalf("expected an invalid json error, got %v", err)
+	}
+
+	_, _, err = client.decode([]byte{schemaRegistryMagicByte, 0, 0, 0, 22, '{'})
+	if !errors.Is(err, errInvalidJSONPayload) {
+		t.Fatalf("expected an invalid json error, got %v", err)
+	}
+
+	// payloads that are not in the confluent wire format are left to the other decoders
+	text, messageSchema, err := client.decode([]byte(`{"plain": true}`))
+	if err != nil || text != "" || messageSchema != nil {
+		t.Fatalf("expected a plain payload to be skipped, got %q %+v %v", text, messageSchema, err)
+	}
+}
+
+func TestSchemaRegistryUnauthorized(t *testing.T) {
+	registry := &testSchemaRegistry{
+		username:    "user",
+		password:    "secret",
+		schemasByID: map[int]*registrySchema{30: {Subject: "orders-value", Version: 1, Schema: `"string"`}},
+	}
+
+	client := newTestSchemaRegistry(t, registry)
+	client.settings.Password = "wrong"
+
+	_, err := client.schemaByID(30)
+	if !errors.Is(err, errSchemaRegistryRequest) || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected an unauthorized registry error, got %v", err)
+	}
+}
+
+func assertTestJSONEqual(t *testing.T, expected, actual string) {
+	t.Helper()
+
+	var expectedValue, actualValue interface{}
+
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal([]byte(actual), &actualValue); err != nil {
+		t.Fatalf("invalid json %q: %v", actual, err)
+	}
+
+	expectedJSON, _ := json.Marshal(expectedValue)
+	actualJSON, _ := json.Marshal(actualValue)
+
+	if string(expectedJSON) != string(actualJSON) {
+		t.Fatalf("expected %s, got %s", expectedJSON, actualJSON)
+	}
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/oauth"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+var (
+	errUnknownSecurityProtocol = errors.New("unknown security protocol")
+	errUnknownSASLMechanism    = errors.New("unknown sasl mechanism")
+)
+
+// migrateAuthMethod maps the legacy AuthMethod, still sent by older frontends, onto the security settings.
+func (s *State) migrateAuthMethod() {
+	switch s.AuthMethod {
+	case AuthMethodSASLSSL:
+		s.SecurityProtocol = SecurityProtocolSASLSSL
+
+		if s.SASLMechanism == "" {
+			s.SASLMechanism = SASLMechanismPlain
+		}
+	case AuthMethodPlaintext:
+		s.SecurityProtocol = SecurityProtocolPlaintext
+	}
+
+	if s.SecurityProtocol == "" {
+		s.SecurityProtocol = SecurityProtocolPlaintext
+	}
+
+	s.AuthMethod = ""
+}
+
+func (s *State) validateSecurity() error {
+	switch s.SecurityProtocol {
+	case SecurityProtocolPlaintext, SecurityProtocolSSL, SecurityProtocolSASLPlaintext, SecurityProtocolSASLSSL:
+	default:
+		return fmt.Errorf("%w: %s", errUnknownSecurityProtocol, s.SecurityProtocol)
+	}
+
+	if !s.isSASL() {
+		return nil
+	}
+
+	switch s.SASLMechanism {
+	case SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512, SASLMechanismOAuthBearer:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errUnknownSASLMechanism, s.SASLMechanism)
+	}
+}
+
+func (s *State) isTLS() bool {
+	return s.SecurityProtocol == SecurityProtocolSSL || s.SecurityProtocol == SecurityProtocolSASLSSL
+}
+
+func (s *State) isSASL() bool {
+	return s.SecurityProtocol == SecurityProtocolSASLPlaintext || s.SecurityProtocol == SecurityProtocolSASLSSL
+}
+
+func (s *State) saslMechanism() sasl.Mechanism {
+	switch s.SASLMechanism {
+	case SASLMechanismScramSHA256:
+		return scram.Auth{User: s.AuthUsername, Pass: s.AuthPassword}.AsSha256Mechanism()
+	case SASLMechanismScramSHA512:
+		return scram.Auth{User: s.AuthUsername, Pass: s.AuthPassword}.AsSha512Mechanism()
+	case SASLMechanismOAuthBearer:
+		return oauth.Auth{Token: s.AuthToken}.AsMechanism()
+	default:
+		return plain.Auth{User: s.AuthUsername, Pass: s.AuthPassword}.AsMechanism()
+	}
+}
@@ -1,5 +1,7 @@
 package kafka
 
+import "github.com/catake-com/multibase/backend/tlsconfig"
+
 type AuthMethod string
 
 const (
@@ -7,6 +9,32 @@ const (
 	AuthMethodSASLSSL   = "sasl_ssl"
 )
 
+type SecurityProtocol string
+
+const (
+	SecurityProtocolPlaintext     SecurityProtocol = "PLAINTEXT"
+	SecurityProtocolSSL           SecurityProtocol = "SSL"
+	SecurityProtocolSASLPlaintext SecurityProtocol = "SASL_PLAINTEXT"
+	SecurityProtocolSASLSSL       SecurityProtocol = "SASL_SSL"
+)
+
+type SASLMechanism string
+
+const (
+	SASLMechanismPlain       SASLMechanism = "PLAIN"
+	SASLMechanismScramSHA256 SASLMechanism = "SCRAM-SHA-256"
+	SASLMechanismScramSHA512 SASLMechanism = "SCRAM-SHA-512"
+	SASLMechanismOAuthBearer SASLMechanism = "OAUTHBEARER"
+)
+
+type SchemaType string
+
+const (
+	SchemaTypeAvro     = "AVRO"
+	SchemaTypeProtobuf = "PROTOBUF"
+	SchemaTypeJSON     = "JSON"
+)
+
 type Tab string
 
 const (
@@ -16,6 +44,24 @@ const (
 	TabConsumers = "consumers"
 )
 
+type OffsetResetStrategy string
+
+const (
+	OffsetResetStrategyEarliest  = "earliest"
+	OffsetResetStrategyLatest    = "latest"
+	OffsetResetStrategyOffset    = "offset"
+	OffsetResetStrategyTimestamp = "timestamp"
+	OffsetResetStrategyShift     = "shift"
+)
+
+type ConsumerGroupOperation string
+
+const (
+	ConsumerGroupOperationResetOffsets  = "reset_offsets"
+	ConsumerGroupOperationDeleteOffsets = "delete_offsets"
+	ConsumerGroupOperationDeleteGroup   = "delete_group"
+)
+
 type TopicConsumingStrategy string
 
 const (
@@ -25,14 +71,73 @@ const (
 	TopicConsumingStrategyOffsetOldest   = "offset_oldest"
 )
 
+type ProduceCompression string
+
+const (
+	ProduceCompressionNone   = "none"
+	ProduceCompressionGzip   = "gzip"
+	ProduceCompressionSnappy = "snappy"
+	ProduceCompressionLZ4    = "lz4"
+	ProduceCompressionZstd   = "zstd"
+)
+
+type ProduceAcks string
+
+const (
+	ProduceAcksNone   = "none"
+	ProduceAcksLeader = "leader"
+	ProduceAcksAll    = "all"
+)
+
 type State struct {
-	ID           string     `json:"id"`
-	Address      string     `json:"address"`
-	AuthMethod   AuthMethod `json:"authMethod"`
-	AuthUsername string     `json:"authUsername"`
-	AuthPassword string     `json:"authPassword"`
-	IsConnected  bool       `json:"isConnected"`
-	CurrentTab   Tab        `json:"currentTab"`
+	ID      string `json:"id"`
+	Address string `json:"address"`
+	// AuthMethod is the legacy auth setting, it is mapped onto SecurityProtocol whenever it is set.
+	AuthMethod       AuthMethod          `json:"authMethod,omitempty"`
+	SecurityProtocol SecurityProtocol    `json:"securityProtocol"`
+	SASLMechanism    SASLMechanism       `json:"saslMechanism"`
+	AuthUsername     string              `json:"authUsername"`
+	AuthPassword     string              `json:"authPassword"`
+	AuthToken        string              `json:"authToken"`
+	TLS              *tlsconfig.Settings `json:"tls"`
+	SchemaRegistry   *SchemaRegistry     `json:"schemaRegistry"`
+	// ProtoImportPathList, ProtoFileList and ProtoDescriptorSetList are
+	// the local sources of protobuf message types.
+	ProtoImportPathList    []string             `json:"protoImportPathList"`
+	ProtoFileList          []string             `json:"protoFileList"`
+	ProtoDescriptorSetList []string             `json:"protoDescriptorSetList"`
+	TopicProtoMappings     []*TopicProtoMapping `json:"topicProtoMappings"`
+	// ConsumingBufferSize is how many of the last consumed messages are kept for paging, 0 keeps the default.
+	ConsumingBufferSize int  `json:"consumingBufferSize"`
+	IsConnected         bool `json:"isConnected"`
+	CurrentTab          Tab  `json:"currentTab"`
+}
+
+type SchemaRegistry struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// TopicProtoMapping decodes the keys and values of a topic as raw protobuf of the given fully qualified message types,
+// an empty type leaves that part to the other decoders.
+type TopicProtoMapping struct {
+	Topic            string `json:"topic"`
+	KeyMessageType   string `json:"keyMessageType"`
+	ValueMessageType string `json:"valueMessageType"`
+}
+
+type TabOverviewData struct {
+	IsConnected                   bool   `json:"isConnected"`
+	ClusterID                     string `json:"clusterID"`
+	ControllerID                  int    `json:"controllerID"`
+	BrokerCount                   int    `json:"brokerCount"`
+	TopicCount                    int    `json:"topicCount"`
+	PartitionCount                int    `json:"partitionCount"`
+	UnderReplicatedPartitionCount int    `json:"underReplicatedPartitionCount"`
+	OfflinePartitionCount         int    `json:"offlinePartitionCount"`
+	TotalSize                     int64  `json:"totalSize"`
+	Error                         string `json:"error"`
 }
 
 type TabTopicsData struct {
@@ -47,6 +152,52 @@ type TabTopicsDataTopic struct {
 	MessageCount   int64  `json:"messageCount"`
 }
 
+type TopicDetails struct {
+	Name       string                   `json:"name"`
+	ID         string                   `json:"id"`
+	IsInternal bool                     `json:"isInternal"`
+	Partitions []*TopicPartitionDetails `json:"partitions"`
+}
+
+type TopicPartitionDetails struct {
+	ID                int    `json:"id"`
+	Leader            int    `json:"leader"`
+	LeaderEpoch       int    `json:"leaderEpoch"`
+	Replicas          []int  `json:"replicas"`
+	ISR               []int  `json:"isr"`
+	OfflineReplicas   []int  `json:"offlineReplicas"`
+	IsOffline         bool   `json:"isOffline"`
+	IsUnderReplicated bool   `json:"isUnderReplicated"`
+	Error             string `json:"error"`
+}
+
+type TopicCreation struct {
+	Name              string            `json:"name"`
+	PartitionCount    int               `json:"partitionCount"`
+	ReplicationFactor int               `json:"replicationFactor"`
+	Configs           map[string]string `json:"configs"`
+}
+
+type TopicConfigs struct {
+	TopicName string         `json:"topicName"`
+	List      []*ConfigEntry `json:"list"`
+}
+
+type ConfigEntry struct {
+	Name         string `json:"name"`
+	Value        string `json:"value"`
+	DefaultValue string `json:"defaultValue"`
+	Source       string `json:"source"`
+	IsOverridden bool   `json:"isOverridden"`
+	IsSensitive  bool   `json:"isSensitive"`
+}
+
+type ConfigChange struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	IsDeleted bool   `json:"isDeleted"`
+}
+
 type TabBrokersData struct {
 	IsConnected bool                    `json:"isConnected"`
 	Count       int                     `json:"count"`
@@ -54,10 +205,42 @@ type TabBrokersData struct {
 }
 
 type TabBrokersDataBroker struct {
-	ID   int    `json:"id"`
-	Rack string `json:"rack"`
-	Host string `json:"host"`
-	Port int    `json:"port"`
+	ID           int    `json:"id"`
+	Rack         string `json:"rack"`
+	Host         string `json:"host"`
+	Port         int    `json:"port"`
+	IsController bool   `json:"isController"`
+}
+
+type BrokerDetails struct {
+	Broker       *TabBrokersDataBroker `json:"broker"`
+	KafkaVersion string                `json:"kafkaVersion"`
+	Configs      []*ConfigEntry        `json:"configs"`
+	LogDirs      []*BrokerLogDir       `json:"logDirs"`
+	APIVersions  []*BrokerAPIVersion   `json:"apiVersions"`
+	TotalSize    int64                 `json:"totalSize"`
+}
+
+type BrokerLogDir struct {
+	Path       string                   `json:"path"`
+	Size       int64                    `json:"size"`
+	Partitions []*BrokerLogDirPartition `json:"partitions"`
+	Error      string                   `json:"error"`
+}
+
+type BrokerLogDirPartition struct {
+	Topic       string `json:"topic"`
+	PartitionID int    `json:"partitionID"`
+	Size        int64  `json:"size"`
+	OffsetLag   int64  `json:"offsetLag"`
+	IsFuture    bool   `json:"isFuture"`
+}
+
+type BrokerAPIVersion struct {
+	Key        int    `json:"key"`
+	Name       string `json:"name"`
+	MinVersion int    `json:"minVersion"`
+	MaxVersion int    `json:"maxVersion"`
 }
 
 type TabConsumersData struct {
@@ -67,8 +250,71 @@ type TabConsumersData struct {
 }
 
 type TabConsumersDataConsumer struct {
-	Name  string `json:"name"`
-	State string `json:"state"`
+	Name         string `json:"name"`
+	State        string `json:"state"`
+	MembersCount int    `json:"membersCount"`
+}
+
+type ConsumerGroupOffsetsReset struct {
+	Strategy     OffsetResetStrategy `json:"strategy"`
+	Topic        string              `json:"topic"`
+	PartitionIDs []int               `json:"partitionIDs"`
+	Offset       int64               `json:"offset"`
+	Timestamp    string              `json:"timestamp"`
+	Shift        int64               `json:"shift"`
+}
+
+type ConsumerGroupOperationPlan struct {
+	GroupName         string                                 `json:"groupName"`
+	Operation         ConsumerGroupOperation                 `json:"operation"`
+	ConfirmationToken string                                 `json:"confirmationToken"`
+	IsApplied         bool                                   `json:"isApplied"`
+	Partitions        []*ConsumerGroupOperationPlanPartition `json:"partitions"`
+}
+
+type ConsumerGroupOperationPlanPartition struct {
+	Topic          string `json:"topic"`
+	PartitionID    int    `json:"partitionID"`
+	CurrentOffset  int64  `json:"currentOffset"`
+	TargetOffset   int64  `json:"targetOffset"`
+	LogStartOffset int64  `json:"logStartOffset"`
+	LogEndOffset   int64  `json:"logEndOffset"`
+	Error          string `json:"error"`
+}
+
+type ConsumerGroup struct {
+	Name         string                    `json:"name"`
+	State        string                    `json:"state"`
+	ProtocolType string                    `json:"protocolType"`
+	Protocol     string                    `json:"protocol"`
+	Coordinator  *TabBrokersDataBroker     `json:"coordinator"`
+	Members      []*ConsumerGroupMember    `json:"members"`
+	Partitions   []*ConsumerGroupPartition `json:"partitions"`
+	LagTotal     int64                     `json:"lagTotal"`
+}
+
+type ConsumerGroupMember struct {
+	MemberID    string                     `json:"memberID"`
+	InstanceID  string                     `json:"instanceID"`
+	ClientID    string                     `json:"clientID"`
+	ClientHost  string                     `json:"clientHost"`
+	Assignments []*ConsumerGroupAssignment `json:"assignments"`
+}
+
+type ConsumerGroupAssignment struct {
+	Topic        string `json:"topic"`
+	PartitionIDs []int  `json:"partitionIDs"`
+}
+
+type ConsumerGroupPartition struct {
+	Topic           string `json:"topic"`
+	PartitionID     int    `json:"partitionID"`
+	CommittedOffset int64  `json:"committedOffset"`
+	LogEndOffset    int64  `json:"logEndOffset"`
+	Lag             int64  `json:"lag"`
+	MemberID        string `json:"memberID"`
+	ClientID        string `json:"clientID"`
+	Error           string `json:"error"`
 }
 
 type TopicOutput struct {
@@ -79,18 +325,72 @@ type TopicOutput struct {
 	Partitions    []*TopicPartition `json:"partitions"`
 }
 
+// TopicConsumingFilter narrows down the consumed messages before they are emitted, empty fields match everything.
+// TimeTo and OffsetTo bound the consuming, it stops once every partition has passed the bound or its end.
+type TopicConsumingFilter struct {
+	KeyContains    string               `json:"keyContains"`
+	KeyRegex       string               `json:"keyRegex"`
+	ValueContains  string               `json:"valueContains"`
+	ValueRegex     string               `json:"valueRegex"`
+	ValueJSONPaths []*JSONPathCondition `json:"valueJSONPaths"`
+	// Headers without a value only have to be present.
+	Headers      []*MessageHeader `json:"headers"`
+	PartitionIDs []int            `json:"partitionIDs"`
+	TimeTo       string           `json:"timeTo"`
+	OffsetTo     *int64           `json:"offsetTo"`
+}
+
+// JSONPathCondition matches decoded JSON values where any result of the path equals the value,
+// strings compare as they are and everything else by its JSON encoding.
+type JSONPathCondition struct {
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+type TopicConsumingProgress struct {
+	ScannedCount int64 `json:"scannedCount"`
+	MatchedCount int64 `json:"matchedCount"`
+	IsFinished   bool  `json:"isFinished"`
+}
+
+// TopicConsumingOutput is emitted in batches, SkippedCount messages consumed since the previous batch did not fit
+// into it and can be paged from the buffer.
 type TopicConsumingOutput struct {
-	Messages []*TopicMessage `json:"messages"`
+	Messages     []*TopicMessage `json:"messages"`
+	SkippedCount int64           `json:"skippedCount"`
+	Topic        *TopicOutput    `json:"topic"`
+}
+
+type TopicMessagesPage struct {
+	Messages      []*TopicMessage `json:"messages"`
+	HasMore       bool            `json:"hasMore"`
+	BufferedCount int             `json:"bufferedCount"`
+	DroppedCount  int64           `json:"droppedCount"`
+	Topic         *TopicOutput    `json:"topic"`
 }
 
 type TopicMessage struct {
-	TimestampUnix      int64  `json:"timestampUnix"`
-	TimestampFormatted string `json:"timestampFormatted"`
-	PartitionID        int    `json:"partitionID"`
-	Offset             int64  `json:"offset"`
-	Key                string `json:"key"`
-	Data               string `json:"data"`
-	Headers            string `json:"headers"`
+	// Sequence numbers the messages of a consuming in the order they were consumed, starting from 1.
+	Sequence           int64          `json:"sequence"`
+	TimestampUnix      int64          `json:"timestampUnix"`
+	TimestampFormatted string         `json:"timestampFormatted"`
+	PartitionID        int            `json:"partitionID"`
+	Offset             int64          `json:"offset"`
+	Key                string         `json:"key"`
+	KeySchema          *MessageSchema `json:"keySchema"`
+	Data               string         `json:"data"`
+	DataSchema         *MessageSchema `json:"dataSchema"`
+	Headers            string         `json:"headers"`
+}
+
+type MessageSchema struct {
+	ID      int        `json:"id"`
+	Type    SchemaType `json:"type"`
+	Subject string     `json:"subject"`
+	Version int        `json:"version"`
+	// MessageType is the protobuf message type of a topic proto mapping.
+	MessageType string `json:"messageType"`
+	Error       string `json:"error"`
 }
 
 type TopicPartition struct {
@@ -100,3 +400,39 @@ type TopicPartition struct {
 	OffsetCurrentStart int64 `json:"offsetCurrentStart"`
 	OffsetCurrentEnd   int64 `json:"offsetCurrentEnd"`
 }
+
+type MessageHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ProduceMessage is a message to produce, IsNullValue sends a tombstone instead of the value.
+type ProduceMessage struct {
+	Partition   *int32           `json:"partition"`
+	Key         string           `json:"key"`
+	Value       string           `json:"value"`
+	IsNullValue bool             `json:"isNullValue"`
+	Headers     []*MessageHeader `json:"headers"`
+	Timestamp   string           `json:"timestamp"`
+}
+
+type ProduceOptions struct {
+	Compression ProduceCompression `json:"compression"`
+	Acks        ProduceAcks        `json:"acks"`
+	// KeySchemaSubject and ValueSchemaSubject encode keys and values with the latest schema of the subject.
+	KeySchemaSubject   string `json:"keySchemaSubject"`
+	ValueSchemaSubject string `json:"valueSchemaSubject"`
+}
+
+type ProduceResult struct {
+	PartitionID        int    `json:"partitionID"`
+	Offset             int64  `json:"offset"`
+	TimestampFormatted string `json:"timestampFormatted"`
+	Error              string `json:"error"`
+}
+
+type ProduceOutput struct {
+	Count      int              `json:"count"`
+	ErrorCount int              `json:"errorCount"`
+	Results    []*ProduceResult `json:"results"`
+}
@@ -0,0 +1,281 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+var (
+	errEmptyTopicName           = errors.New("empty topic name")
+	errInvalidPartitionCount    = errors.New("invalid partition count")
+	errInvalidReplicationFactor = errors.New("invalid replication factor")
+	errNoConfigChanges          = errors.New("no config changes")
+	errEmptyConfigChangeName    = errors.New("empty config change name")
+	errTopicConfigsNotFound     = errors.New("topic configs not found")
+)
+
+func (p *Project) TopicDetails(topicName string) (*TopicDetails, error) {
+	if !p.state.IsConnected {
+		return nil, errNotConnected
+	}
+
+	kafkaTopics, err := p.client.ListTopics(context.Background(), topicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+
+	kafkaTopic, ok := kafkaTopics[topicName]
+	if !ok || kafkaTopic.Err != nil {
+		return nil, fmt.Errorf("%w: %s", errTopicNotFound, topicName)
+	}
+
+	topicDetails := &TopicDetails{
+		Name:       kafkaTopic.Topic,
+		ID:         kafkaTopic.ID.String(),
+		IsInternal: kafkaTopic.IsInternal,
+		Partitions: make([]*TopicPartitionDetails, 0, len(kafkaTopic.Partitions)),
+	}
+
+	for _, partitionID := range kafkaTopic.Partitions.Numbers() {
+		kafkaPartition := kafkaTopic.Partitions[partitionID]
+
+		partition := &TopicPartitionDetails{
+			ID:                int(kafkaPartition.Partition),
+			Leader:            int(kafkaPartition.Leader),
+			LeaderEpoch:       int(kafkaPartition.LeaderEpoch),
+			Replicas:          brokerIDs(kafkaPartition.Replicas),
+			ISR:               brokerIDs(kafkaPartition.ISR),
+			OfflineReplicas:   brokerIDs(kafkaPartition.OfflineReplicas),
+			IsOffline:         kafkaPartition.Leader < 0,
+			IsUnderReplicated: len(kafkaPartition.ISR) < len(kafkaPartition.Replicas),
+		}
+
+		if kafkaPartition.Err != nil {
+			partition.Error = kafkaPartition.Err.Error()
+		}
+
+		topicDetails.Partitions = append(topicDetails.Partitions, partition)
+	}
+
+	return topicDetails, nil
+}
+
+func (p *Project) CreateTopic(creation *TopicCreation) (*TopicDetails, error) {
+	if !p.state.IsConnected {
+		return nil, errNotConnected
+	}
+
+	if creation.Name == "" {
+		return nil, errEmptyTopicName
+	}
+
+	if creation.PartitionCount < 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidPartitionCount, creation.PartitionCount)
+	}
+
+	if creation.ReplicationFactor < 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidReplicationFactor, creation.ReplicationFactor)
+	}
+
+	// -1 lets the brokers apply their num.partitions and default.replication.factor
+	partitionCount := int32(-1)
+	if creation.PartitionCount > 0 {
+		partitionCount = int32(creation.PartitionCount)
+	}
+
+	replicationFactor := int16(-1)
+	if creation.ReplicationFactor > 0 {
+		replicationFactor = int16(creation.ReplicationFactor)
+	}
+
+	configs := make(map[string]*string, len(creation.Configs))
+	for name, value := range creation.Configs {
+		configs[name] = kadm.StringPtr(value)
+	}
+
+	_, err := p.client.CreateTopic(context.Background(), partitionCount, replicationFactor, configs, creation.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create topic: %w", err)
+	}
+
+	return p.TopicDetails(creation.Name)
+}
+
+func (p *Project) DeleteTopic(topicName string) (*TabTopicsData, error) {
+	if !p.state.IsConnected {
+		return nil, errNotConnected
+	}
+
+	responses, err := p.client.DeleteTopics(context.Background(), topicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete topic: %w", err)
+	}
+
+	response, ok := responses[topicName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errTopicNotFound, topicName)
+	}
+
+	if response.Err != nil {
+		return nil, fmt.Errorf("failed to delete topic: %w", response.Err)
+	}
+
+	return p.Topics()
+}
+
+func (p *Project) AddTopicPartitions(topicName string, count int) (*TopicDetails, error) {
+	if !p.state.IsConnected {
+		return nil, errNotConnected
+	}
+
+	if count <= 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidPartitionCount, count)
+	}
+
+	responses, err := p.client.CreatePartitions(context.Background(), count, topicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add partitions: %w", err)
+	}
+
+	response, ok := responses[topicName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errTopicNotFound, topicName)
+	}
+
+	if response.Err != nil {
+		return nil, fmt.Errorf("failed to add partitions: %w", response.Err)
+	}
+
+	return p.TopicDetails(topicName)
+}
+
+func (p *Project) TopicConfigs(topicName string) (*TopicConfigs, error) {
+	if !p.state.IsConnected {
+		return nil, errNotConnected
+	}
+
+	resourceConfigs, err := p.client.DescribeTopicConfigs(context.Background(), topicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+
+	resourceConfig, err := resourceConfigs.On(topicName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errTopicConfigsNotFound, topicName)
+	}
+
+	if resourceConfig.Err != nil {
+		return nil, fmt.Errorf("failed to describe topic configs: %w", resourceConfig.Err)
+	}
+
+	return &TopicConfigs{
+		TopicName: topicName,
+		List:      newConfigEntries(resourceConfig.Configs, kmsg.ConfigSourceDynamicTopicConfig),
+	}, nil
+}
+
+func (p *Project) AlterTopicConfigs(topicName string, changes []*ConfigChange) (*TopicConfigs, error) {
+	if !p.state.IsConnected {
+		return nil, errNotConnected
+	}
+
+	alterConfigs, err := newAlterConfigs(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := p.client.AlterTopicConfigs(context.Background(), alterConfigs, topicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to alter topic configs: %w", err)
+	}
+
+	_, err = responses.On(topicName, func(response *kadm.AlterConfigsResponse) error { return response.Err })
+	if err != nil {
+		return nil, fmt.Errorf("failed to alter topic configs: %w", err)
+	}
+
+	return p.TopicConfigs(topicName)
+}
+
+func newAlterConfigs(changes []*ConfigChange) ([]kadm.AlterConfig, error) {
+	if len(changes) == 0 {
+		return nil, errNoConfigChanges
+	}
+
+	alterConfigs := make([]kadm.AlterConfig, 0, len(changes))
+
+	for _, change := range changes {
+		if change.Name == "" {
+			return nil, errEmptyConfigChangeName
+		}
+
+		if change.IsDeleted {
+			alterConfigs = append(alterConfigs, kadm.AlterConfig{Op: kadm.DeleteConfig, Name: change.Name})
+
+			continue
+		}
+
+		alterConfigs = append(
+			alterConfigs,
+			kadm.AlterConfig{Op: kadm.SetConfig, Name: change.Name, Value: kadm.StringPtr(change.Value)},
+		)
+	}
+
+	return alterConfigs, nil
+}
+
+// newConfigEntries converts described configs, the ones coming from overrideSource are the overridden ones.
+func newConfigEntries(configs []kadm.Config, overrideSource kmsg.ConfigSource) []*ConfigEntry {
+	entries := make([]*ConfigEntry, 0, len(configs))
+
+	for _, config := range configs {
+		entry := &ConfigEntry{
+			Name:         config.Key,
+			Value:        config.MaybeValue(),
+			Source:       config.Source.String(),
+			IsOverridden: config.Source == overrideSource,
+			IsSensitive:  config.Sensitive,
+		}
+
+		if !entry.IsOverridden {
+			entry.DefaultValue = entry.Value
+		} else {
+			entry.DefaultValue = overriddenDefaultValue(config)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+// overriddenDefaultValue returns what applies without the override,
+// synonyms go from the most to the least specific source.
+func overriddenDefaultValue(config kadm.Config) string {
+	for _, synonym := range config.Synonyms {
+		if synonym.Source != config.Source && synonym.Value != nil {
+			return *synonym.Value
+		}
+	}
+
+	return ""
+}
+
+func brokerIDs(ids []int32) []int {
+	result := make([]int, 0, len(ids))
+
+	for _, id := range ids {
+		result = append(result, int(id))
+	}
+
+	return result
+}
@@ -0,0 +1,138 @@
+package thrift
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	maxRetries   = 10
+	retryBackoff = 100 * time.Millisecond
+)
+
+var (
+	errInvalidTimeout  = errors.New("timeout must not be negative")
+	errInvalidRetries  = errors.New("retries must be between 0 and 10")
+	errInvalidProxyURL = errors.New("proxy url must use http, https or socks5 scheme")
+)
+
+type ClientSettings struct {
+	TimeoutMs int64  `json:"timeoutMs"`
+	Retries   int    `json:"retries"`
+	ProxyURL  string `json:"proxyURL"`
+	KeepAlive bool   `json:"keepAlive"`
+	HTTP2     bool   `json:"http2"`
+}
+
+func newClientSettings() *ClientSettings {
+	return &ClientSettings{
+		TimeoutMs: requestTimeout.Milliseconds(),
+		KeepAlive: true,
+		HTTP2:     true,
+	}
+}
+
+func (s *ClientSettings) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if s.TimeoutMs < 0 {
+		return errInvalidTimeout
+	}
+
+	if s.Retries < 0 || s.Retries > maxRetries {
+		return errInvalidRetries
+	}
+
+	_, err := s.proxyURL()
+
+	return err
+}
+
+func (s *ClientSettings) timeout() time.Duration {
+	if s == nil || s.TimeoutMs <= 0 {
+		return requestTimeout
+	}
+
+	return time.Duration(s.TimeoutMs) * time.Millisecond
+}
+
+func (s *ClientSettings) retries() int {
+	if s == nil {
+		return 0
+	}
+
+	return s.Retries
+}
+
+func (s *ClientSettings) proxyURL() (*url.URL, error) {
+	if s == nil || s.ProxyURL == "" {
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(s.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse a proxy url: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+		return proxyURL, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errInvalidProxyURL, s.ProxyURL)
+	}
+}
+
+func (s *ClientSettings) httpTransport(tlsConfig *tls.Config) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone() // nolint: forcetypeassert
+	transport.TLSClientConfig = tlsConfig
+
+	proxyURL, err := s.proxyURL()
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if s != nil {
+		transport.DisableKeepAlives = !s.KeepAlive
+
+		if !s.HTTP2 {
+			transport.ForceAttemptHTTP2 = false
+			transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		}
+	}
+
+	return transport, nil
+}
+
+// isConnectionError reports whether a request failed to connect, so the server has not received it.
+// Errors of an established connection, like a reset, may come after the request is processed,
+// so they are not retried to keep non-idempotent requests from being sent twice.
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+
+	return (errors.As(err, &opErr) && opErr.Op == "dial") || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func waitForRetry(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(retryBackoff * time.Duration(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("thrift request canceled: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,148 @@
+package thrift
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	errEnvironmentNotFound = errors.New("environment not found")
+	errUndefinedVariable   = errors.New("undefined environment variable")
+)
+
+var variablePattern = regexp.MustCompile(`{{\s*([A-Za-z0-9_.-]+)\s*}}`)
+
+type Environment struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Variables []*EnvironmentVariable `json:"variables"`
+}
+
+type EnvironmentVariable struct {
+	ID    string `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// requestTemplate is a request as typed in the form, before environment variables are substituted.
+type requestTemplate struct {
+	functionID string
+	address    string
+	headers    []*Header
+	payload    string
+}
+
+// resolvedCall is a request with all environment variables substituted.
+type resolvedCall struct {
+	functionID string
+	address    string
+	headers    []*Header
+	payload    string
+}
+
+func (e *Environment) variables() map[string]string {
+	variables := make(map[string]string)
+
+	if e == nil {
+		return variables
+	}
+
+	for _, variable := range e.Variables {
+		if variable.Key == "" {
+			continue
+		}
+
+		variables[variable.Key] = variable.Value
+	}
+
+	return variables
+}
+
+func substituteVariables(template string, variables map[string]string) (string, error) {
+	var undefinedNames []string
+
+	result := variablePattern.ReplaceAllStringFunc(template, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+
+		value, ok := variables[name]
+		if !ok {
+			undefinedNames = append(undefinedNames, name)
+
+			return match
+		}
+
+		return value
+	})
+
+	if len(undefinedNames) > 0 {
+		return "", fmt.Errorf("%w: %s", errUndefinedVariable, strings.Join(undefinedNames, ", "))
+	}
+
+	return result, nil
+}
+
+func resolveCall(
+	environment *Environment,
+	functionID, address, payload string,
+	headers []*Header,
+) (*resolvedCall, error) {
+	variables := environment.variables()
+
+	resolvedAddress, err := substituteVariables(address, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedPayload, err := substituteVariables(payload, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedHeaders := make([]*Header, 0, len(headers))
+
+	for _, header := range headers {
+		key, err := substituteVariables(header.Key, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := substituteVariables(header.Value, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		resolvedHeaders = append(resolvedHeaders, &Header{ID: header.ID, Key: key, Value: value})
+	}
+
+	return &resolvedCall{
+		functionID: functionID,
+		address:    resolvedAddress,
+		headers:    resolvedHeaders,
+		payload:    resolvedPayload,
+	}, nil
+}
+
+func (p *Project) environment(environmentID string) (*Environment, error) {
+	for _, environment := range p.Environments {
+		if environment.ID == environmentID {
+			return environment, nil
+		}
+	}
+
+	return nil, errEnvironmentNotFound
+}
+
+func (p *Project) currentEnvironment() *Environment {
+	if p.CurrentEnvironmentID == "" {
+		return nil
+	}
+
+	environment, err := p.environment(p.CurrentEnvironmentID)
+	if err != nil {
+		return nil
+	}
+
+	return environment
+}
@@ -9,122 +9,429 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
+	"github.com/uber/tchannel-go"
 	"github.com/yarpc/yab/thrift"
+	"go.uber.org/thriftrw/compile"
 	"gopkg.in/yaml.v3"
+
+	"github.com/catake-com/multibase/backend/tlsconfig"
 )
 
 const requestTimeout = 5 * time.Second
 
+var errFormClosed = errors.New("form is closed")
+
 type Form struct {
-	ID                 string    `json:"id"`
-	Address            string    `json:"address"`
-	Headers            []*Header `json:"headers"`
-	SelectedFunctionID string    `json:"selectedFunctionID"`
-	IsMultiplexed      bool      `json:"isMultiplexed"`
-	Request            string    `json:"request"`
-	Response           string    `json:"response"`
-
-	client            *http.Client
-	serviceTree       *ServiceTree
-	requestCancelFunc context.CancelFunc
-}
-
-// nolint: funlen
-func (f *Form) SendRequest(
-	functionID,
-	address,
-	payload string,
-	isMultiplexed bool,
-	headers []*Header,
-) (string, error) {
-	ctx, cancelFunc := context.WithTimeout(context.Background(), requestTimeout)
+	ID                 string              `json:"id"`
+	Address            string              `json:"address"`
+	Headers            []*Header           `json:"headers"`
+	SelectedFunctionID string              `json:"selectedFunctionID"`
+	IsMultiplexed      bool                `json:"isMultiplexed"`
+	Transport          Transport           `json:"transport"`
+	Protocol           Protocol            `json:"protocol"`
+	Scheme             string              `json:"scheme"`
+	URLPath            string              `json:"urlPath"`
+	TLS                *tlsconfig.Settings `json:"tls"`
+	YARPC              *YARPC              `json:"yarpc"`
+	ClientSettings     *ClientSettings     `json:"clientSettings"`
+	PayloadMode        PayloadMode         `json:"payloadMode"`
+	IsDebug            bool                `json:"isDebug"`
+	Request            string              `json:"request"`
+	RequestFields      []*SkeletonField    `json:"requestFields"`
+	Response           string              `json:"response"`
+
+	client             *http.Client
+	clientTLS          tlsconfig.Settings
+	clientSettings     ClientSettings
+	tchannel           *tchannel.Channel
+	isClosed           bool
+	clientMutex        sync.Mutex
+	requestCancelFunc  context.CancelFunc
+	requestCancelMutex sync.Mutex
+}
+
+// formRequest is what a request is sent with: the resolved call along with a copy of the form settings,
+// which is taken under the project state mutex, as the form may be edited while the request is in flight.
+type formRequest struct {
+	call           *resolvedCall
+	function       *ServiceTreeFunction
+	isMultiplexed  bool
+	transport      Transport
+	protocol       Protocol
+	scheme         string
+	urlPath        string
+	tls            tlsconfig.Settings
+	yarpc          YARPC
+	clientSettings ClientSettings
+	payloadMode    PayloadMode
+	isDebug        bool
+}
+
+// newRequest must be called under the project state mutex, missing settings get their defaults.
+func (f *Form) newRequest(call *resolvedCall, function *ServiceTreeFunction) *formRequest {
+	request := &formRequest{
+		call:           call,
+		function:       function,
+		isMultiplexed:  f.IsMultiplexed,
+		transport:      f.Transport,
+		protocol:       f.Protocol,
+		scheme:         f.Scheme,
+		urlPath:        f.URLPath,
+		yarpc:          YARPC{Caller: defaultYARPCCaller},
+		clientSettings: *newClientSettings(),
+		payloadMode:    f.PayloadMode,
+		isDebug:        f.IsDebug,
+	}
+
+	if f.TLS != nil {
+		request.tls = *f.TLS
+	}
+
+	if f.YARPC != nil {
+		request.yarpc = *f.YARPC
+	}
+
+	if f.ClientSettings != nil {
+		request.clientSettings = *f.ClientSettings
+	}
+
+	// tchannel always carries thrift in the binary protocol
+	if request.transport == TransportTChannel {
+		request.protocol = ProtocolBinary
+	}
+
+	return request
+}
+
+// SendRequest reads nothing but the request and the clients of the form, which are guarded by their own mutex.
+// Every attempt gets the whole timeout, retries happen on connection errors only.
+func (f *Form) SendRequest(request *formRequest) (string, error) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	f.setRequestCancelFunc(cancelFunc)
+	defer f.setRequestCancelFunc(nil)
+
+	function := request.function
+
+	encodedPayload, err := encodePayload(request, function)
+	if err != nil {
+		return "", err
+	}
+
+	procedure := fmt.Sprintf("%s::%s", function.ServiceName(), function.Spec().Name)
+
+	var (
+		responseBody    []byte
+		responseHeaders map[string]string
+		httpResponse    *ResponseHTTP
+	)
+
+	for attempt := 0; ; attempt++ {
+		responseBody, responseHeaders, httpResponse, err = f.executeAttempt(
+			ctx,
+			request,
+			procedure,
+			encodedPayload,
+		)
+
+		isRetryable := attempt < request.clientSettings.retries() && isConnectionError(err)
+
+		if err == nil || !isRetryable || ctx.Err() != nil {
+			break
+		}
+
+		if waitErr := waitForRetry(ctx, attempt+1); waitErr != nil {
+			err = waitErr
+
+			break
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return "", err
+	}
+
+	// a response that cannot be decoded still carries its headers and wire dumps along with the error
+	response, decodeErr := buildResponse(request.protocol, function, responseBody, httpResponse, err)
+	if decodeErr != nil {
+		response = &ResponseJSON{Error: decodeErr.Error()}
+	}
+
+	response.Headers = responseHeaders
+	response.HTTP = httpResponse
+
+	if request.isDebug {
+		response.Debug = newResponseDebug(request, encodedPayload, responseBody)
+	}
+
+	responseJSON, err := marshalResponse(response)
+	if err != nil {
+		return "", err
+	}
+
+	return responseJSON, decodeErr
+}
+
+// newResponseDebug dumps the messages, TChannel carries them without envelopes,
+// so its dumps show the envelopes rebuilt around the exchanged arguments.
+func newResponseDebug(request *formRequest, payload, responseBody []byte) *ResponseDebug {
+	isReconstructed := request.transport == TransportTChannel
+
+	debug := &ResponseDebug{Request: newWireDump(request.protocol, request.function.Spec(), payload)}
+	debug.Request.IsReconstructed = isReconstructed
+
+	if len(responseBody) > 0 {
+		debug.Response = newWireDump(request.protocol, request.function.Spec(), responseBody)
+		debug.Response.IsReconstructed = isReconstructed
+	}
+
+	return debug
+}
+
+func (f *Form) executeAttempt(
+	ctx context.Context,
+	request *formRequest,
+	procedure string,
+	payload []byte,
+) ([]byte, map[string]string, *ResponseHTTP, error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, request.clientSettings.timeout())
+	defer cancelFunc()
+
+	switch request.transport {
+	case TransportFramed, TransportBuffered:
+		responseBody, err := executeSocketRequest(
+			ctx,
+			request.transport,
+			request.protocol,
+			request.call.address,
+			payload,
+			request.function.IsOneWay(),
+		)
+
+		return responseBody, nil, nil, err
+	case TransportTChannel:
+		responseBody, responseHeaders, err := f.executeTChannelRequest(ctx, request, procedure, payload)
+
+		return responseBody, responseHeaders, nil, err
+	default:
+		responseBody, httpResponse, err := f.executeHTTPRequest(ctx, request, procedure, payload)
+
+		return responseBody, nil, httpResponse, err
+	}
+}
+
+func (f *Form) StopCurrentRequest() {
+	f.requestCancelMutex.Lock()
+	defer f.requestCancelMutex.Unlock()
+
+	if f.requestCancelFunc == nil {
+		return
+	}
+
+	f.requestCancelFunc()
+	f.requestCancelFunc = nil
+}
+
+// Close stops the request in flight and closes the clients for good, it is called once the form is removed.
+func (f *Form) Close() error {
+	f.StopCurrentRequest()
+
+	f.clientMutex.Lock()
+	defer f.clientMutex.Unlock()
+
+	f.isClosed = true
+	f.resetClient()
+	f.resetTChannel()
+
+	return nil
+}
+
+func (f *Form) setRequestCancelFunc(cancelFunc context.CancelFunc) {
+	f.requestCancelMutex.Lock()
+	defer f.requestCancelMutex.Unlock()
+
 	f.requestCancelFunc = cancelFunc
+}
 
-	function := f.serviceTree.Function(functionID)
+// encodePayload builds the message to send, either from the structured request or,
+// in raw mode, from the bytes the user supplied as they are.
+func encodePayload(request *formRequest, function *ServiceTreeFunction) ([]byte, error) {
+	if isRawPayloadMode(request.payloadMode) {
+		return decodeRawPayload(request.payloadMode, request.call.payload)
+	}
 
 	var requestPayload map[string]interface{}
 
-	err := yaml.Unmarshal([]byte(payload), &requestPayload)
+	err := yaml.Unmarshal([]byte(request.call.payload), &requestPayload)
 	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal payload: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
 	}
 
 	thriftOptions := thrift.Options{UseEnvelopes: true}
 
-	if isMultiplexed {
+	if request.isMultiplexed {
 		thriftOptions.EnvelopeMethodPrefix = fmt.Sprintf("%s:", function.ServiceName())
 	}
 
-	encodedPayload, err := thrift.RequestToBytes(function.Spec(), requestPayload, thriftOptions)
+	binaryPayload, err := thrift.RequestToBytes(function.Spec(), requestPayload, thriftOptions)
 	if err != nil {
-		return "", fmt.Errorf("failed to build thrift encoded payload: %w", err)
+		return nil, fmt.Errorf("failed to build thrift encoded payload: %w", err)
 	}
 
-	requestURL := &url.URL{Scheme: "http", Host: address}
+	return transcodeRequest(request.protocol, function.Spec(), binaryPayload)
+}
 
-	request, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		requestURL.String(),
-		bytes.NewReader(encodedPayload),
-	)
+// buildResponse turns the outcome of an exchange into a response, reporting transport
+// failures and unexpected http statuses as transport exceptions.
+func buildResponse(
+	protocolName Protocol,
+	function *ServiceTreeFunction,
+	responseBody []byte,
+	httpResponse *ResponseHTTP,
+	err error,
+) (*ResponseJSON, error) {
 	if err != nil {
-		return "", fmt.Errorf("failed to build thrift request: %w", err)
+		return newTransportExceptionResponse(err, httpResponse), nil
 	}
 
-	for _, header := range headers {
-		request.Header.Add(header.Key, header.Value)
+	if function.IsOneWay() {
+		if httpResponse != nil && !isSuccessfulHTTPStatus(httpResponse.StatusCode) {
+			return newTransportExceptionResponse(
+				unexpectedHTTPStatusError(httpResponse, responseBody),
+				httpResponse,
+			), nil
+		}
+
+		return &ResponseJSON{Status: ResponseStatusOneWay}, nil
 	}
 
-	responseBody, err := f.executeRequest(request)
+	response, err := parseResponseBody(protocolName, function.Spec(), responseBody)
 	if err != nil {
-		return "", err
+		if httpResponse == nil || isSuccessfulHTTPStatus(httpResponse.StatusCode) {
+			return nil, err
+		}
+
+		return newTransportExceptionResponse(
+			unexpectedHTTPStatusError(httpResponse, responseBody),
+			httpResponse,
+		), nil
 	}
 
-	thriftResponse, err := thrift.ResponseBytesToMap(
-		function.Spec(),
-		responseBody,
-		thrift.Options{UseEnvelopes: true},
-	)
+	return response, nil
+}
+
+func parseResponseBody(
+	protocolName Protocol,
+	function *compile.FunctionSpec,
+	responseBody []byte,
+) (*ResponseJSON, error) {
+	binaryResponse, err := transcodeResponse(protocolName, function, responseBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse(function, binaryResponse)
+}
+
+func (f *Form) executeHTTPRequest(
+	ctx context.Context,
+	formRequest *formRequest,
+	procedure string,
+	payload []byte,
+) ([]byte, *ResponseHTTP, error) {
+	scheme, urlPath, err := normalizeHTTPSettings(formRequest.scheme, formRequest.urlPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse thrift response: %w", err)
+		return nil, nil, err
 	}
 
-	jsonResponse, err := json.Marshal(thriftResponse)
+	requestURL := &url.URL{Scheme: scheme, Host: formRequest.call.address, Path: urlPath}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		requestURL.String(),
+		bytes.NewReader(payload),
+	)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal a response: %w", err)
+		return nil, nil, fmt.Errorf("failed to build thrift request: %w", err)
 	}
 
-	return string(jsonResponse), nil
+	timeout := requestTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	formRequest.yarpc.applyHTTPHeaders(request.Header, procedure, timeout)
+
+	for _, header := range formRequest.call.headers {
+		request.Header.Add(header.Key, header.Value)
+	}
+
+	return f.executeRequest(request, formRequest)
 }
 
-func (f *Form) StopCurrentRequest() {
-	if f.requestCancelFunc == nil {
+func (f *Form) ResetClient() {
+	f.clientMutex.Lock()
+	defer f.clientMutex.Unlock()
+
+	f.resetClient()
+}
+
+func (f *Form) resetClient() {
+	if f.client == nil {
 		return
 	}
 
-	f.requestCancelFunc()
-	f.requestCancelFunc = nil
+	f.client.CloseIdleConnections()
+	f.client = nil
 }
 
-func (f *Form) Close() error {
-	if f.client != nil {
-		f.client.CloseIdleConnections()
+// fetchClient rebuilds the client once the request comes with settings other than the client was built with,
+// a closed form builds none, so that a request in flight leaves nothing open after the form is removed.
+func (f *Form) fetchClient(request *formRequest) (*http.Client, error) {
+	f.clientMutex.Lock()
+	defer f.clientMutex.Unlock()
+
+	if f.isClosed {
+		return nil, errFormClosed
 	}
 
-	return nil
+	if f.client != nil && f.clientTLS == request.tls && f.clientSettings == request.clientSettings {
+		return f.client, nil
+	}
+
+	f.resetClient()
+
+	tlsConfig, err := request.tls.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := request.clientSettings.httpTransport(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	f.client = &http.Client{
+		Transport: transport,
+	}
+	f.clientTLS = request.tls
+	f.clientSettings = request.clientSettings
+
+	return f.client, nil
 }
 
-func (f *Form) executeRequest(request *http.Request) (_ []byte, rerr error) {
-	if f.client == nil {
-		f.client = &http.Client{
-			Timeout: requestTimeout,
-		}
+func (f *Form) executeRequest(request *http.Request, formRequest *formRequest) (_ []byte, _ *ResponseHTTP, rerr error) {
+	client, err := f.fetchClient(formRequest)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	response, err := f.client.Do(request)
+	response, err := client.Do(request)
 	defer func() {
 		if response == nil {
 			return
@@ -137,13 +444,42 @@ func (f *Form) executeRequest(request *http.Request) (_ []byte, rerr error) {
 	}()
 
 	if err != nil {
-		return nil, fmt.Errorf("http request failed: %w", err)
+		return nil, nil, fmt.Errorf("http request failed: %w", err)
 	}
 
+	httpResponse := newResponseHTTP(response)
+
 	responseBody, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, httpResponse, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return responseBody, httpResponse, nil
+}
+
+func marshalResponse(response *ResponseJSON) (string, error) {
+	jsonResponse, err := json.Marshal(response)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal a response: %w", err)
+	}
+
+	return string(jsonResponse), nil
+}
+
+func isSuccessfulHTTPStatus(statusCode int) bool {
+	return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+}
+
+func unexpectedHTTPStatusError(httpResponse *ResponseHTTP, responseBody []byte) error {
+	return fmt.Errorf("%w %d: %s", errUnexpectedHTTPStatus, httpResponse.StatusCode, truncate(responseBody))
+}
+
+func truncate(body []byte) string {
+	const maxLength = 512
+
+	if len(body) > maxLength {
+		return string(body[:maxLength]) + "..."
 	}
 
-	return responseBody, nil
+	return string(body)
 }
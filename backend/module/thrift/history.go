@@ -0,0 +1,107 @@
+package thrift
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const historyLimit = 100
+
+var (
+	errHistoryEntryNotFound = errors.New("history entry not found")
+	errSavedRequestNotFound = errors.New("saved request not found")
+)
+
+// HistoryEntry keeps the request as typed in the form, its variables stay unresolved so the entry can be sent
+// with another environment. Environments themselves, header values included, are stored with the project as is.
+type HistoryEntry struct {
+	ID              string    `json:"id"`
+	FunctionID      string    `json:"functionID"`
+	Address         string    `json:"address"`
+	Headers         []*Header `json:"headers"`
+	Request         string    `json:"request"`
+	EnvironmentName string    `json:"environmentName"`
+	Response        string    `json:"response"`
+	Error           string    `json:"error"`
+	SentAt          string    `json:"sentAt"`
+	DurationMs      int64     `json:"durationMs"`
+}
+
+type SavedRequest struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	FunctionID string    `json:"functionID"`
+	Address    string    `json:"address"`
+	Headers    []*Header `json:"headers"`
+	Request    string    `json:"request"`
+}
+
+func newHistoryEntry(
+	template *requestTemplate,
+	environmentName string,
+	sentAt time.Time,
+	response string,
+	err error,
+) *HistoryEntry {
+	entry := &HistoryEntry{
+		ID:              uuid.Must(uuid.NewV4()).String(),
+		FunctionID:      template.functionID,
+		Address:         template.address,
+		Headers:         template.headers,
+		Request:         template.payload,
+		EnvironmentName: environmentName,
+		Response:        response,
+		SentAt:          sentAt.Format(time.RFC3339Nano),
+		DurationMs:      time.Since(sentAt).Milliseconds(),
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	return entry
+}
+
+func (p *Project) recordHistoryEntry(entry *HistoryEntry) {
+	p.History = append([]*HistoryEntry{entry}, p.History...)
+
+	if len(p.History) > historyLimit {
+		p.History = p.History[:historyLimit]
+	}
+}
+
+func (p *Project) historyEntry(entryID string) (*HistoryEntry, error) {
+	for _, entry := range p.History {
+		if entry.ID == entryID {
+			return entry, nil
+		}
+	}
+
+	return nil, errHistoryEntryNotFound
+}
+
+func (p *Project) savedRequest(savedRequestID string) (*SavedRequest, error) {
+	for _, savedRequest := range p.SavedRequests {
+		if savedRequest.ID == savedRequestID {
+			return savedRequest, nil
+		}
+	}
+
+	return nil, errSavedRequestNotFound
+}
+
+func copyHeaders(headers []*Header) []*Header {
+	result := make([]*Header, 0, len(headers))
+
+	for _, header := range headers {
+		result = append(result, &Header{
+			ID:    uuid.Must(uuid.NewV4()).String(),
+			Key:   header.Key,
+			Value: header.Value,
+		})
+	}
+
+	return result
+}
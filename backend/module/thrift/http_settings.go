@@ -0,0 +1,37 @@
+package thrift
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	SchemeHTTP  = "http"
+	SchemeHTTPS = "https"
+)
+
+var (
+	errUnknownScheme   = errors.New("unknown url scheme")
+	defaultHTTPURLPath = "/"
+)
+
+func normalizeHTTPSettings(scheme, urlPath string) (string, string, error) {
+	if scheme == "" {
+		scheme = SchemeHTTP
+	}
+
+	if scheme != SchemeHTTP && scheme != SchemeHTTPS {
+		return "", "", fmt.Errorf("%w: %s", errUnknownScheme, scheme)
+	}
+
+	if urlPath == "" {
+		urlPath = defaultHTTPURLPath
+	}
+
+	if !strings.HasPrefix(urlPath, "/") {
+		urlPath = "/" + urlPath
+	}
+
+	return scheme, urlPath, nil
+}
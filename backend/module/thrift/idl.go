@@ -0,0 +1,97 @@
+package thrift
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// includeFS resolves thrift includes relative to the including file first,
+// then falls back to the configured include paths.
+type includeFS struct {
+	includePathList []string
+	// resolvedDirs maps a directory requested by the compiler to the directory the file was found in.
+	resolvedDirs map[string]string
+}
+
+func newIncludeFS(includePathList []string) *includeFS {
+	return &includeFS{
+		includePathList: includePathList,
+		resolvedDirs:    make(map[string]string),
+	}
+}
+
+func (f *includeFS) Read(filename string) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if err == nil {
+		f.resolvedDirs[filepath.Dir(filename)] = filepath.Dir(filename)
+
+		return data, nil
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err // nolint: wrapcheck
+	}
+
+	requestedDirs := make([]string, 0, len(f.resolvedDirs))
+	for requestedDir := range f.resolvedDirs {
+		requestedDirs = append(requestedDirs, requestedDir)
+	}
+
+	sort.Slice(requestedDirs, func(i, j int) bool {
+		return len(requestedDirs[i]) > len(requestedDirs[j])
+	})
+
+	for _, requestedDir := range requestedDirs {
+		relativePath, relErr := filepath.Rel(requestedDir, filename)
+		if relErr != nil || strings.HasPrefix(relativePath, "..") {
+			continue
+		}
+
+		searchDirs := append([]string{f.resolvedDirs[requestedDir]}, f.includePathList...)
+
+		for _, searchDir := range searchDirs {
+			resolvedPath := filepath.Join(searchDir, relativePath)
+
+			data, readErr := os.ReadFile(resolvedPath)
+			if readErr != nil {
+				continue
+			}
+
+			f.resolvedDirs[filepath.Dir(filename)] = filepath.Dir(resolvedPath)
+
+			return data, nil
+		}
+	}
+
+	return nil, err // nolint: wrapcheck
+}
+
+func (f *includeFS) Abs(p string) (string, error) {
+	return filepath.Abs(p) // nolint: wrapcheck
+}
+
+func compileFiles(includePathList, fileList []string) ([]*compile.Module, error) {
+	modules := make([]*compile.Module, 0, len(fileList))
+
+	for _, filePath := range fileList {
+		module, err := compile.Compile(
+			filePath,
+			compile.NonStrict(),
+			compile.Filesystem(newIncludeFS(includePathList)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed compile thrift: %w", err)
+		}
+
+		modules = append(modules, module)
+	}
+
+	return modules, nil
+}
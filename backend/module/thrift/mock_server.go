@@ -0,0 +1,561 @@
+package thrift
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/yarpc/yab/thrift"
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	mockCallLimit                     = 100
+	defaultMockListenAddress          = "127.0.0.1:9091"
+	applicationExceptionUnknownMethod = 1
+	applicationExceptionInternalError = 6
+)
+
+var (
+	errMockServerAlreadyRunning = errors.New("mock server is already running")
+	errMockServerNotRunning     = errors.New("mock server is not running")
+	errMockUnknownException     = errors.New("function does not declare the exception")
+	errMockUnsupportedTransport = errors.New("mock server supports http, framed and buffered transports only")
+	errMockInvalidLatency       = errors.New("latency must not be negative")
+)
+
+type MockServer struct {
+	ListenAddress string                   `json:"listenAddress"`
+	Transport     Transport                `json:"transport"`
+	Protocol      Protocol                 `json:"protocol"`
+	IsRunning     bool                     `json:"isRunning"`
+	Responses     map[string]*MockResponse `json:"responses"`
+	Calls         []*MockCall              `json:"calls"`
+
+	listener    net.Listener
+	httpServer  *http.Server
+	connections map[net.Conn]struct{}
+	serviceTree *ServiceTree
+	onCall      func(call *MockCall)
+	mutex       sync.RWMutex
+}
+
+// MockResponse is what the mock server replies with for a function.
+// An empty ExceptionName means Body is the return value, otherwise Body holds the exception fields.
+type MockResponse struct {
+	FunctionID    string `json:"functionID"`
+	ExceptionName string `json:"exceptionName"`
+	Body          string `json:"body"`
+	LatencyMs     int64  `json:"latencyMs"`
+}
+
+type MockCall struct {
+	ID         string `json:"id"`
+	FunctionID string `json:"functionID"`
+	Method     string `json:"method"`
+	RemoteAddr string `json:"remoteAddr"`
+	Request    string `json:"request"`
+	Response   string `json:"response"`
+	Error      string `json:"error"`
+	ReceivedAt string `json:"receivedAt"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+func newMockServer() *MockServer {
+	return &MockServer{
+		ListenAddress: defaultMockListenAddress,
+		Transport:     TransportHTTP,
+		Protocol:      ProtocolBinary,
+		Responses:     make(map[string]*MockResponse),
+	}
+}
+
+// Start listens with the given settings, they are kept on the server only once it is listening.
+func (s *MockServer) Start(
+	listenAddress string,
+	transport Transport,
+	protocol Protocol,
+	serviceTree *ServiceTree,
+	onCall func(call *MockCall),
+) error {
+	if s.listener != nil {
+		return errMockServerAlreadyRunning
+	}
+
+	if transport != TransportHTTP && transport != TransportFramed && transport != TransportBuffered {
+		return fmt.Errorf("%w: %s", errMockUnsupportedTransport, transport)
+	}
+
+	if err := validateProtocol(protocol); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", listenAddress, err)
+	}
+
+	s.ListenAddress = listenAddress
+	s.Transport = transport
+	s.Protocol = protocol
+	s.setServiceTree(serviceTree)
+	s.listener = listener
+	s.onCall = onCall
+	s.IsRunning = true
+
+	if s.Transport == TransportHTTP {
+		protocolName := s.Protocol
+
+		s.httpServer = &http.Server{ // nolint: gosec
+			Handler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				s.handleHTTP(writer, request, protocolName)
+			}),
+		}
+
+		go func(server *http.Server) {
+			_ = server.Serve(listener)
+		}(s.httpServer)
+
+		return nil
+	}
+
+	go s.acceptConnections(listener, s.Transport, s.Protocol)
+
+	return nil
+}
+
+func (s *MockServer) Stop() error {
+	if s.listener == nil {
+		return errMockServerNotRunning
+	}
+
+	var err error
+
+	if s.httpServer != nil {
+		err = s.httpServer.Close()
+	} else {
+		err = s.listener.Close()
+		s.closeConnections()
+	}
+
+	s.listener = nil
+	s.httpServer = nil
+	s.IsRunning = false
+
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		return fmt.Errorf("failed to stop the mock server: %w", err)
+	}
+
+	return nil
+}
+
+func (s *MockServer) Close() error {
+	if s.listener == nil {
+		return nil
+	}
+
+	return s.Stop()
+}
+
+func (s *MockServer) SetResponse(response *MockResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.Responses == nil {
+		s.Responses = make(map[string]*MockResponse)
+	}
+
+	s.Responses[response.FunctionID] = response
+}
+
+func (r *MockResponse) Validate() error {
+	if r.LatencyMs < 0 {
+		return fmt.Errorf("%w: %d", errMockInvalidLatency, r.LatencyMs)
+	}
+
+	return nil
+}
+
+func (s *MockServer) Record(call *MockCall) {
+	s.Calls = append([]*MockCall{call}, s.Calls...)
+
+	if len(s.Calls) > mockCallLimit {
+		s.Calls = s.Calls[:mockCallLimit]
+	}
+}
+
+func (s *MockServer) setServiceTree(serviceTree *ServiceTree) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.serviceTree = serviceTree
+}
+
+func (s *MockServer) handleHTTP(writer http.ResponseWriter, request *http.Request, protocolName Protocol) {
+	message, err := io.ReadAll(request.Body)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	reply := s.handleMessage(protocolName, message, request.RemoteAddr)
+
+	writer.Header().Set("Content-Type", "application/x-thrift")
+	_, _ = writer.Write(reply)
+}
+
+func (s *MockServer) acceptConnections(listener net.Listener, transport Transport, protocolName Protocol) {
+	for {
+		connection, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mutex.Lock()
+		if s.connections == nil {
+			s.connections = make(map[net.Conn]struct{})
+		}
+		s.connections[connection] = struct{}{}
+		s.mutex.Unlock()
+
+		go s.serveConnection(connection, transport, protocolName)
+	}
+}
+
+func (s *MockServer) closeConnections() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for connection := range s.connections {
+		_ = connection.Close()
+	}
+
+	s.connections = nil
+}
+
+func (s *MockServer) serveConnection(connection net.Conn, transport Transport, protocolName Protocol) {
+	defer func() {
+		s.mutex.Lock()
+		delete(s.connections, connection)
+		s.mutex.Unlock()
+
+		_ = connection.Close()
+	}()
+
+	reader := bufio.NewReader(connection)
+
+	for {
+		var (
+			message []byte
+			err     error
+		)
+
+		if transport == TransportFramed {
+			message, err = readFrame(reader)
+		} else {
+			message, err = readMessage(protocolName, reader)
+		}
+
+		if err != nil {
+			return
+		}
+
+		reply := s.handleMessage(protocolName, message, connection.RemoteAddr().String())
+		if reply == nil {
+			continue
+		}
+
+		if transport == TransportFramed {
+			frameHeader := make([]byte, 4) // nolint: gomnd
+			binary.BigEndian.PutUint32(frameHeader, uint32(len(reply)))
+			reply = append(frameHeader, reply...)
+		}
+
+		if _, err := connection.Write(reply); err != nil {
+			return
+		}
+	}
+}
+
+// handleMessage decodes an incoming call, replies with the configured response and reports the call.
+// It returns nil for oneway calls and for messages that can not be decoded at all.
+func (s *MockServer) handleMessage(protocolName Protocol, message []byte, remoteAddr string) []byte {
+	receivedAt := time.Now()
+
+	call := &MockCall{
+		ID:         uuid.Must(uuid.NewV4()).String(),
+		RemoteAddr: remoteAddr,
+		ReceivedAt: receivedAt.Format(time.RFC3339Nano),
+	}
+
+	reply, err := s.reply(protocolName, message, call)
+	if err != nil {
+		call.Error = err.Error()
+	}
+
+	call.DurationMs = time.Since(receivedAt).Milliseconds()
+
+	if s.onCall != nil {
+		s.onCall(call)
+	}
+
+	return reply
+}
+
+// nolint: cyclop
+func (s *MockServer) reply(protocolName Protocol, message []byte, call *MockCall) ([]byte, error) {
+	envelope, err := decodeMessage(protocolName, nil, message)
+	if err != nil {
+		return nil, err
+	}
+
+	call.Method = envelope.Name
+
+	s.mutex.RLock()
+	serviceTree := s.serviceTree
+	s.mutex.RUnlock()
+
+	function, err := serviceTree.FunctionByName(envelope.Name)
+	if err != nil {
+		return s.applicationException(protocolName, envelope, applicationExceptionUnknownMethod, err), err
+	}
+
+	call.FunctionID = function.id
+
+	if protocolName == ProtocolJSON {
+		envelope, err = decodeMessage(protocolName, function.Spec(), message)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	call.Request, err = decodeMockArgs(function.Spec(), envelope.Value)
+	if err != nil {
+		return s.applicationException(protocolName, envelope, applicationExceptionInternalError, err), err
+	}
+
+	s.mutex.RLock()
+	response := s.Responses[function.id]
+	s.mutex.RUnlock()
+
+	if response != nil && response.LatencyMs > 0 {
+		time.Sleep(time.Duration(response.LatencyMs) * time.Millisecond)
+	}
+
+	if function.IsOneWay() {
+		return nil, nil
+	}
+
+	result, err := mockResult(function.Spec(), response)
+	if err != nil {
+		return s.applicationException(protocolName, envelope, applicationExceptionInternalError, err), err
+	}
+
+	replyEnvelope := wire.Envelope{
+		Name:  envelope.Name,
+		Type:  wire.Reply,
+		SeqID: envelope.SeqID,
+		Value: result,
+	}
+
+	reply, err := encodeMessage(protocolName, function.Spec(), replyEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	call.Response, err = decodeMockResult(function.Spec(), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (s *MockServer) applicationException(
+	protocolName Protocol,
+	envelope wire.Envelope,
+	exceptionType int32,
+	err error,
+) []byte {
+	if envelope.Type == wire.OneWay {
+		return nil
+	}
+
+	reply, encodeErr := encodeMessage(protocolName, nil, wire.Envelope{
+		Name:  envelope.Name,
+		Type:  wire.Exception,
+		SeqID: envelope.SeqID,
+		Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+			{ID: 1, Value: wire.NewValueString(err.Error())},
+			{ID: 2, Value: wire.NewValueI32(exceptionType)}, // nolint: gomnd
+		}}),
+	})
+	if encodeErr != nil {
+		return nil
+	}
+
+	return reply
+}
+
+// mockResult builds the result struct of a function with yab's encoder, using the default
+// skeleton of the return type when no response is configured.
+func mockResult(function *compile.FunctionSpec, response *MockResponse) (wire.Value, error) {
+	resultFields := compile.FieldGroup{}
+	if spec, ok := envelopeSpec(function, wire.Reply).(*compile.StructSpec); ok {
+		resultFields = spec.Fields
+	}
+
+	body, fieldName, err := mockResultBody(function, response)
+	if err != nil {
+		return wire.Value{}, err
+	}
+
+	result := map[string]interface{}{}
+
+	if fieldName != "" {
+		var value interface{}
+
+		err := yaml.Unmarshal([]byte(body), &value)
+		if err != nil {
+			return wire.Value{}, fmt.Errorf("failed to unmarshal mock response: %w", err)
+		}
+
+		result[fieldName] = value
+	}
+
+	resultBytes, err := thrift.RequestToBytes(
+		&compile.FunctionSpec{Name: function.Name, ArgsSpec: compile.ArgsSpec(resultFields)},
+		result,
+		thrift.Options{},
+	)
+	if err != nil {
+		return wire.Value{}, fmt.Errorf("failed to build thrift encoded mock response: %w", err)
+	}
+
+	value, err := protocol.Binary.Decode(bytes.NewReader(resultBytes), wire.TStruct)
+	if err != nil {
+		return wire.Value{}, fmt.Errorf("failed to decode thrift encoded mock response: %w", err)
+	}
+
+	return value, nil
+}
+
+// mockResultBody returns the body of a response and the result field it belongs to.
+func mockResultBody(function *compile.FunctionSpec, response *MockResponse) (string, string, error) {
+	if response != nil && response.ExceptionName != "" {
+		if function.ResultSpec != nil {
+			for _, exceptionSpec := range function.ResultSpec.Exceptions {
+				if exceptionSpec.Name == response.ExceptionName {
+					return response.Body, exceptionSpec.Name, nil
+				}
+			}
+		}
+
+		return "", "", fmt.Errorf("%w: %s", errMockUnknownException, response.ExceptionName)
+	}
+
+	if function.ResultSpec == nil || function.ResultSpec.ReturnType == nil {
+		return "", "", nil
+	}
+
+	if response != nil && response.Body != "" {
+		return response.Body, "success", nil
+	}
+
+	body, err := defaultMockBody(function)
+	if err != nil {
+		return "", "", err
+	}
+
+	return body, "success", nil
+}
+
+func defaultMockBody(function *compile.FunctionSpec) (string, error) {
+	skeleton, _, err := buildSkeleton(compile.FieldGroup{
+		{ID: 0, Name: "success", Type: function.ResultSpec.ReturnType},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	success, _ := skeleton.Get("success")
+
+	body, err := json.Marshal(success)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal a default mock response: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// decodeMockArgs converts call arguments into JSON with yab's decoder, the arguments struct is wrapped
+// as the result of a synthetic function since results are the only spec-guided decoding yab exposes.
+func decodeMockArgs(function *compile.FunctionSpec, value wire.Value) (string, error) {
+	argsFunction := &compile.FunctionSpec{
+		Name: function.Name,
+		ResultSpec: &compile.ResultSpec{
+			ReturnType: &compile.StructSpec{
+				Name:   function.Name + "_args",
+				Type:   ast.StructType,
+				Fields: compile.FieldGroup(function.ArgsSpec),
+			},
+		},
+	}
+
+	fields, err := decodeMockFields(argsFunction, wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 0, Value: value},
+	}}))
+	if err != nil {
+		return "", err
+	}
+
+	return marshalMockFields(fields["result"])
+}
+
+func decodeMockResult(function *compile.FunctionSpec, value wire.Value) (string, error) {
+	fields, err := decodeMockFields(function, value)
+	if err != nil {
+		return "", err
+	}
+
+	return marshalMockFields(fields)
+}
+
+func decodeMockFields(function *compile.FunctionSpec, value wire.Value) (map[string]interface{}, error) {
+	buffer := &bytes.Buffer{}
+
+	err := protocol.Binary.Encode(value, buffer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode a thrift struct: %w", err)
+	}
+
+	fields, err := thrift.ResponseBytesToMap(function, buffer.Bytes(), thrift.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a thrift struct: %w", err)
+	}
+
+	return fields, nil
+}
+
+func marshalMockFields(fields interface{}) (string, error) {
+	body, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal a thrift struct: %w", err)
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,113 @@
+package thrift
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+func TestDecodeMockArgs(t *testing.T) {
+	serviceTree := newTestServiceTree(t)
+
+	function := testFunction(t, serviceTree, "greet")
+
+	// field ID 0 is not a result here, clients may send it as an unknown field
+	args := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 0, Value: wire.NewValueString("unknown")},
+		{ID: 1, Value: wire.NewValueString("multibase")},
+		{ID: 2, Value: wire.NewValueI64(2)},
+	}})
+
+	request, err := decodeMockArgs(function.Spec(), args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertJSONEqual(t, map[string]interface{}{"name": "multibase", "times": 2}, request)
+}
+
+func TestServiceTreeFunctionByName(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "services.thrift")
+
+	err := os.WriteFile(filePath, []byte(`
+service Greeter {
+  string greet(1: string name)
+  void ping()
+}
+
+service Pinger {
+  void ping()
+}
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := compileFiles(nil, []string{filePath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceTree, err := NewServiceTree(modules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	function, err := serviceTree.FunctionByName("greet")
+	if err != nil || function.ServiceName() != "Greeter" {
+		t.Fatalf("unexpected function %v: %v", function, err)
+	}
+
+	function, err = serviceTree.FunctionByName("Pinger:ping")
+	if err != nil || function.ServiceName() != "Pinger" {
+		t.Fatalf("unexpected function %v: %v", function, err)
+	}
+
+	_, err = serviceTree.FunctionByName("ping")
+	if !errors.Is(err, errThriftAmbiguousFunction) {
+		t.Fatalf("expected an ambiguous function error, got %v", err)
+	}
+
+	_, err = serviceTree.FunctionByName("Greeter:missing")
+	if !errors.Is(err, errThriftUnknownFunction) {
+		t.Fatalf("expected an unknown function error, got %v", err)
+	}
+}
+
+func TestMockServerStartKeepsSettingsOnFailure(t *testing.T) {
+	mockServer := newMockServer()
+
+	err := mockServer.Start("127.0.0.1:0", TransportTChannel, ProtocolBinary, nil, nil)
+	if !errors.Is(err, errMockUnsupportedTransport) {
+		t.Fatalf("expected an unsupported transport error, got %v", err)
+	}
+
+	err = mockServer.Start("256.0.0.1:0", TransportFramed, ProtocolCompact, nil, nil)
+	if err == nil {
+		t.Fatal("expected a listen error")
+	}
+
+	if mockServer.ListenAddress != defaultMockListenAddress ||
+		mockServer.Transport != TransportHTTP ||
+		mockServer.Protocol != ProtocolBinary ||
+		mockServer.IsRunning {
+		t.Fatalf("unexpected mock server settings %+v", mockServer)
+	}
+}
+
+func TestProjectSaveMockResponseRejectsUndeclaredException(t *testing.T) {
+	serviceTree := newTestServiceTree(t)
+	project := &Project{serviceTree: serviceTree}
+
+	err := project.SaveMockResponse(&MockResponse{
+		FunctionID:    testFunction(t, serviceTree, "greet").id,
+		ExceptionName: "timeout",
+		Body:          "{}",
+	})
+	if !errors.Is(err, errMockUnknownException) {
+		t.Fatalf("expected an unknown exception error, got %v", err)
+	}
+}
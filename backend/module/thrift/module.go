@@ -8,6 +8,7 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
 	"github.com/catake-com/multibase/backend/state"
+	"github.com/catake-com/multibase/backend/tlsconfig"
 )
 
 const defaultProjectSplitterWidth = 20
@@ -85,6 +86,71 @@ func (m *Module) OpenFilePath(projectID string) (*Project, error) {
 	return project, nil
 }
 
+func (m *Module) RemoveFilePath(projectID, filePath string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.RemoveFilePath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) DeleteAllFiles(projectID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.DeleteAllFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) OpenIncludePath(projectID string) (*Project, error) {
+	includePath, err := runtime.OpenDirectoryDialog(m.AppCtx, runtime.OpenDialogOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open include path: %w", err)
+	}
+
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if includePath == "" {
+		return project, nil
+	}
+
+	err = project.OpenIncludePath(includePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) RemoveIncludePath(projectID, includePath string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.RemoveIncludePath(includePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
 func (m *Module) SelectFunction(projectID, formID, functionID string) (*Project, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -99,6 +165,24 @@ func (m *Module) SelectFunction(projectID, formID, functionID string) (*Project,
 	return project, nil
 }
 
+func (m *Module) FunctionSchema(projectID, functionID string) (*FunctionSchema, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.FunctionSchema(functionID)
+}
+
+func (m *Module) FileSchemas(projectID string) ([]*FileSchema, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.FileSchemas(), nil
+}
+
 func (m *Module) SaveCurrentFormID(projectID, currentFormID string) (*Project, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -141,6 +225,110 @@ func (m *Module) SaveIsMultiplexed(projectID, formID string, isMultiplexed bool)
 	return project, nil
 }
 
+func (m *Module) SaveTransport(projectID, formID string, transport Transport) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveTransport(formID, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SaveProtocol(projectID, formID string, protocol Protocol) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveProtocol(formID, protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SavePayloadMode(projectID, formID string, payloadMode PayloadMode) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SavePayloadMode(formID, payloadMode)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SaveDebug(projectID, formID string, isDebug bool) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveDebug(formID, isDebug)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SaveHTTPSettings(
+	projectID,
+	formID,
+	scheme,
+	urlPath string,
+	tlsSettings *tlsconfig.Settings,
+) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveHTTPSettings(formID, scheme, urlPath, tlsSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SaveYARPC(projectID, formID string, yarpc *YARPC) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveYARPC(formID, yarpc)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SaveClientSettings(projectID, formID string, clientSettings *ClientSettings) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveClientSettings(formID, clientSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
 func (m *Module) AddHeader(projectID, formID string) (*Project, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -272,6 +460,146 @@ func (m *Module) RemoveForm(projectID, formID string) (*Project, error) {
 	return project, nil
 }
 
+func (m *Module) ClearHistory(projectID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.ClearHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) DeleteHistoryEntry(projectID, entryID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.DeleteHistoryEntry(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) RestoreHistoryEntry(projectID, formID, entryID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.RestoreHistoryEntry(formID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SaveRequest(projectID, formID, name string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveRequest(formID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) DeleteSavedRequest(projectID, savedRequestID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.DeleteSavedRequest(savedRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) OpenSavedRequest(projectID, formID, savedRequestID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.OpenSavedRequest(formID, savedRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) CreateEnvironment(projectID, name string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.CreateEnvironment(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SaveEnvironment(projectID string, environment *Environment) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveEnvironment(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) DeleteEnvironment(projectID, environmentID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.DeleteEnvironment(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SelectEnvironment(projectID, environmentID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SelectEnvironment(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
 func (m *Module) BeautifyRequest(projectID, formID string) (*Project, error) {
 	project, err := m.fetchProject(projectID)
 	if err != nil {
@@ -295,6 +623,66 @@ func (m *Module) Project(projectID string) (*Project, error) {
 	return project, nil
 }
 
+func (m *Module) StartMockServer(
+	projectID, listenAddress string,
+	transport Transport,
+	protocol Protocol,
+) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.StartMockServer(m.AppCtx, listenAddress, transport, protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) StopMockServer(projectID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.StopMockServer()
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) SaveMockResponse(projectID string, response *MockResponse) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.SaveMockResponse(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (m *Module) ClearMockCalls(projectID string) (*Project, error) {
+	project, err := m.fetchProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = project.ClearMockCalls()
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
 func (m *Module) fetchProject(projectID string) (*Project, error) {
 	m.projectsMutex.RLock()
 	project, ok := m.projects[projectID]
@@ -317,11 +705,31 @@ func (m *Module) fetchProject(projectID string) (*Project, error) {
 
 	project.stateStorage = m.stateStorage
 
-	if project.FilePath != "" {
-		_, err := project.GenerateServiceTreeNodes(project.FilePath)
+	if project.MockServer != nil {
+		project.MockServer.IsRunning = false
+	}
+
+	if project.FilePath != "" && len(project.FileList) == 0 {
+		project.FileList = []string{project.FilePath}
+	}
+
+	project.FilePath = ""
+
+	if len(project.FileList) > 0 {
+		// the saved nodes of legacy projects still hold "Service_function" ids
+		project.Nodes, err = project.GenerateServiceTreeNodes(project.IncludePathList, project.FileList)
 		if err != nil {
 			return nil, err
 		}
+
+		legacyFilePath := project.FileList[0]
+
+		for _, form := range project.Forms {
+			functionID, ok := project.serviceTree.LegacyFunctionID(form.SelectedFunctionID, legacyFilePath)
+			if ok {
+				form.SelectedFunctionID = functionID
+			}
+		}
 	}
 
 	m.projectsMutex.Lock()
@@ -0,0 +1,75 @@
+package thrift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+
+	"github.com/catake-com/multibase/backend/state"
+)
+
+func TestModuleFetchProjectMigratesLegacyFunctionIDs(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+
+	stateStorage, err := state.NewStorage(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = stateStorage.Close()
+	})
+
+	filePath := filepath.Join(t.TempDir(), "greeter.thrift")
+
+	err = os.WriteFile(filePath, []byte(testIDL), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacyProject := &Project{
+		ID:            "legacy",
+		Forms:         map[string]*Form{"form": {ID: "form", SelectedFunctionID: "Greeter_greet"}},
+		FormIDs:       []string{"form"},
+		CurrentFormID: "form",
+		FilePath:      filePath,
+		Nodes: []*ServiceTreeNode{{
+			ID:       "Greeter",
+			Label:    "Greeter",
+			Children: []*ServiceTreeNode{{ID: "Greeter_greet", Label: "greet", Selectable: true}},
+		}},
+	}
+
+	err = stateStorage.Save(legacyProject.ID, legacyProject)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	module, err := NewModule(stateStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := module.Project(legacyProject.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	functionID := project.Forms["form"].SelectedFunctionID
+	if functionID == "Greeter_greet" {
+		t.Fatal("expected the selected function id to be migrated")
+	}
+
+	functionNode := project.Nodes[0].Children[0].Children[0]
+	if functionNode.ID != functionID {
+		t.Fatalf("expected the nodes to hold the migrated id %s, got %s", functionID, functionNode.ID)
+	}
+
+	_, err = module.SelectFunction(legacyProject.ID, "form", functionNode.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
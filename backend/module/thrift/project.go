@@ -1,30 +1,47 @@
 package thrift
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ditashi/jsbeautifier-go/jsbeautifier"
 	"github.com/gofrs/uuid/v5"
 	"github.com/samber/lo"
-	orderedmap "github.com/wk8/go-ordered-map/v2"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 	"go.uber.org/thriftrw/compile"
 
 	"github.com/catake-com/multibase/backend/state"
+	"github.com/catake-com/multibase/backend/tlsconfig"
 )
 
-var errThriftUnknownType = errors.New("unknown type during thrift parsing")
+var (
+	errThriftUnknownType       = errors.New("unknown type during thrift parsing")
+	errThriftUnknownFunction   = errors.New("unknown thrift function")
+	errThriftAmbiguousFunction = errors.New("thrift function name matches several services")
+)
 
 type Project struct {
-	ID            string             `json:"id"`
-	SplitterWidth float64            `json:"splitterWidth"`
-	Forms         map[string]*Form   `json:"forms"`
-	FormIDs       []string           `json:"formIDs"`
-	CurrentFormID string             `json:"currentFormID"`
-	FilePath      string             `json:"filePath"`
-	Nodes         []*ServiceTreeNode `json:"nodes"`
+	ID            string           `json:"id"`
+	SplitterWidth float64          `json:"splitterWidth"`
+	Forms         map[string]*Form `json:"forms"`
+	FormIDs       []string         `json:"formIDs"`
+	CurrentFormID string           `json:"currentFormID"`
+	// FilePath is only read to migrate projects saved before FileList was introduced.
+	FilePath        string             `json:"filePath,omitempty"`
+	FileList        []string           `json:"fileList"`
+	IncludePathList []string           `json:"includePathList"`
+	Nodes           []*ServiceTreeNode `json:"nodes"`
+
+	History              []*HistoryEntry `json:"history"`
+	SavedRequests        []*SavedRequest `json:"savedRequests"`
+	Environments         []*Environment  `json:"environments"`
+	CurrentEnvironmentID string          `json:"currentEnvironmentID"`
+
+	MockServer *MockServer `json:"mockServer"`
 
 	stateMutex   sync.RWMutex
 	stateStorage *state.Storage
@@ -40,11 +57,18 @@ func NewProject(projectID string, stateStorage *state.Storage) (*Project, error)
 		SplitterWidth: defaultProjectSplitterWidth,
 		Forms: map[string]*Form{
 			formID: {
-				ID:            formID,
-				Address:       address,
-				IsMultiplexed: true,
-				Request:       "{}",
-				Response:      "{}",
+				ID:             formID,
+				Address:        address,
+				IsMultiplexed:  true,
+				Transport:      TransportHTTP,
+				Protocol:       ProtocolBinary,
+				Scheme:         SchemeHTTP,
+				URLPath:        defaultHTTPURLPath,
+				YARPC:          newYARPC(),
+				ClientSettings: newClientSettings(),
+				PayloadMode:    PayloadModeStructured,
+				Request:        "{}",
+				Response:       "{}",
 			},
 		},
 		CurrentFormID: formID,
@@ -68,17 +92,55 @@ func (p *Project) CreateNewForm() error {
 	var headers []*Header
 
 	address := "0.0.0.0:9090"
+	transport := TransportHTTP
+	protocol := ProtocolBinary
+	scheme := SchemeHTTP
+	urlPath := defaultHTTPURLPath
+
+	var tlsSettings *tlsconfig.Settings
+
+	yarpc := newYARPC()
+	clientSettings := newClientSettings()
+	payloadMode := PayloadModeStructured
+	isDebug := false
+
 	if p.CurrentFormID != "" {
 		address = p.Forms[p.CurrentFormID].Address
 		headers = p.Forms[p.CurrentFormID].Headers
+		transport = p.Forms[p.CurrentFormID].Transport
+		protocol = p.Forms[p.CurrentFormID].Protocol
+		scheme = p.Forms[p.CurrentFormID].Scheme
+		urlPath = p.Forms[p.CurrentFormID].URLPath
+		tlsSettings = p.Forms[p.CurrentFormID].TLS
+		payloadMode = p.Forms[p.CurrentFormID].PayloadMode
+		isDebug = p.Forms[p.CurrentFormID].IsDebug
+
+		if p.Forms[p.CurrentFormID].YARPC != nil {
+			currentYARPC := *p.Forms[p.CurrentFormID].YARPC
+			yarpc = &currentYARPC
+		}
+
+		if p.Forms[p.CurrentFormID].ClientSettings != nil {
+			currentClientSettings := *p.Forms[p.CurrentFormID].ClientSettings
+			clientSettings = &currentClientSettings
+		}
 	}
 
 	p.Forms[formID] = &Form{
-		ID:       formID,
-		Address:  address,
-		Request:  "{}",
-		Response: "{}",
-		Headers:  headers,
+		ID:             formID,
+		Address:        address,
+		Transport:      transport,
+		Protocol:       protocol,
+		Scheme:         scheme,
+		URLPath:        urlPath,
+		TLS:            tlsSettings,
+		YARPC:          yarpc,
+		ClientSettings: clientSettings,
+		PayloadMode:    payloadMode,
+		IsDebug:        isDebug,
+		Request:        "{}",
+		Response:       "{}",
+		Headers:        headers,
 	}
 	p.FormIDs = append(p.FormIDs, formID)
 	p.CurrentFormID = formID
@@ -115,39 +177,74 @@ func (p *Project) RemoveForm(formID string) error {
 	return p.saveState()
 }
 
+// SendRequest releases the state mutex while the request is in flight, so it can be stopped.
+// The request is sent with a copy of the form settings, so the form may be edited or removed meanwhile.
 func (p *Project) SendRequest(
 	formID,
 	address,
 	payload string,
 ) error {
 	p.stateMutex.Lock()
-	defer p.stateMutex.Unlock()
 
-	p.Forms[formID].Address = address
-	p.Forms[formID].Request = payload
+	form := p.Forms[formID]
+	form.Address = address
+	form.Request = payload
+
+	environment := p.currentEnvironment()
+	template := &requestTemplate{
+		functionID: form.SelectedFunctionID,
+		address:    address,
+		headers:    copyHeaders(form.Headers),
+		payload:    payload,
+	}
 
-	response, err := p.Forms[formID].SendRequest(
-		p.Forms[formID].SelectedFunctionID,
-		address,
-		payload,
-		p.Forms[formID].IsMultiplexed,
-		p.Forms[formID].Headers,
-	)
+	call, err := resolveCall(environment, form.SelectedFunctionID, address, payload, form.Headers)
 	if err != nil {
-		p.Forms[formID].Response = "{}"
+		p.stateMutex.Unlock()
 
 		return err
 	}
 
-	p.Forms[formID].Response = response
+	var environmentName string
+	if environment != nil {
+		environmentName = environment.Name
+	}
 
-	return p.saveState()
-}
+	function := p.serviceTree.Function(call.functionID)
+	if function == nil {
+		p.stateMutex.Unlock()
+
+		return fmt.Errorf("%w: %s", errThriftUnknownFunction, call.functionID)
+	}
+
+	request := form.newRequest(call, function)
+
+	p.stateMutex.Unlock()
+
+	sentAt := time.Now()
+
+	response, err := form.SendRequest(request)
 
-func (p *Project) StopRequest(formID string) {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
 
+	p.recordHistoryEntry(newHistoryEntry(template, environmentName, sentAt, response, err))
+
+	// the form is gone once it has been removed while the request was in flight
+	if p.Forms[formID] == form {
+		form.Response = response
+		if form.Response == "" {
+			form.Response = "{}"
+		}
+	}
+
+	return errors.Join(err, p.saveState())
+}
+
+func (p *Project) StopRequest(formID string) {
+	p.stateMutex.RLock()
+	defer p.stateMutex.RUnlock()
+
 	form := p.Forms[formID]
 
 	form.StopCurrentRequest()
@@ -157,42 +254,127 @@ func (p *Project) OpenFilePath(filePath string) error {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
 
-	nodes, err := p.generateNodes(filePath)
+	if lo.Contains(p.FileList, filePath) {
+		return nil
+	}
+
+	fileList := append(append([]string{}, p.FileList...), filePath)
+
+	nodes, err := p.generateNodes(p.IncludePathList, fileList)
+	if err != nil {
+		return err
+	}
+
+	p.Nodes = nodes
+	p.FileList = fileList
+
+	return p.saveState()
+}
+
+func (p *Project) RemoveFilePath(filePath string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	fileList := lo.Reject(
+		p.FileList,
+		func(fp string, _ int) bool {
+			return fp == filePath
+		},
+	)
+
+	nodes, err := p.generateNodes(p.IncludePathList, fileList)
+	if err != nil {
+		return err
+	}
+
+	p.Nodes = nodes
+	p.FileList = fileList
+
+	return p.saveState()
+}
+
+func (p *Project) DeleteAllFiles() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	nodes, err := p.generateNodes(p.IncludePathList, nil)
 	if err != nil {
 		return err
 	}
 
-	if p.FilePath != "" {
-		for _, form := range p.Forms {
-			if form.ID == p.CurrentFormID {
-				continue
-			}
+	for _, form := range p.Forms {
+		if form.ID == p.CurrentFormID {
+			continue
+		}
 
-			err := form.Close()
-			if err != nil {
-				return err
-			}
+		err := form.Close()
+		if err != nil {
+			return err
 		}
+	}
 
-		form := p.Forms[p.CurrentFormID]
-		form.SelectedFunctionID = ""
-		form.Request = "{}"
-		form.Response = "{}"
+	form := p.Forms[p.CurrentFormID]
+	form.SelectedFunctionID = ""
+	form.RequestFields = nil
+	form.Request = "{}"
+	form.Response = "{}"
 
-		p.Forms = map[string]*Form{form.ID: form}
+	p.Forms = map[string]*Form{form.ID: form}
+	p.FormIDs = []string{form.ID}
+	p.Nodes = nodes
+	p.FileList = nil
+
+	return p.saveState()
+}
+
+func (p *Project) OpenIncludePath(includePath string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if lo.Contains(p.IncludePathList, includePath) {
+		return nil
+	}
+
+	includePathList := append(append([]string{}, p.IncludePathList...), includePath)
+
+	nodes, err := p.generateNodes(includePathList, p.FileList)
+	if err != nil {
+		return err
+	}
+
+	p.Nodes = nodes
+	p.IncludePathList = includePathList
+
+	return p.saveState()
+}
+
+func (p *Project) RemoveIncludePath(includePath string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	includePathList := lo.Reject(
+		p.IncludePathList,
+		func(ip string, _ int) bool {
+			return ip == includePath
+		},
+	)
+
+	nodes, err := p.generateNodes(includePathList, p.FileList)
+	if err != nil {
+		return err
 	}
 
 	p.Nodes = nodes
-	p.FilePath = filePath
+	p.IncludePathList = includePathList
 
 	return p.saveState()
 }
 
-func (p *Project) GenerateServiceTreeNodes(filePath string) ([]*ServiceTreeNode, error) {
+func (p *Project) GenerateServiceTreeNodes(includePathList, fileList []string) ([]*ServiceTreeNode, error) {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
 
-	serviceTreeNodes, err := p.generateNodes(filePath)
+	serviceTreeNodes, err := p.generateNodes(includePathList, fileList)
 	if err != nil {
 		return nil, err
 	}
@@ -205,15 +387,13 @@ func (p *Project) SelectFunction(formID, functionID string) error {
 	defer p.stateMutex.Unlock()
 
 	function := p.serviceTree.Function(functionID)
-	payload := orderedmap.New[string, interface{}]()
-
-	for _, argsSpec := range function.Spec().ArgsSpec {
-		v, err := parseThriftType(argsSpec.Type)
-		if err != nil {
-			return err
-		}
+	if function == nil {
+		return fmt.Errorf("%w: %s", errThriftUnknownFunction, functionID)
+	}
 
-		payload.Set(argsSpec.Name, v)
+	payload, requestFields, err := buildSkeleton(compile.FieldGroup(function.Spec().ArgsSpec))
+	if err != nil {
+		return err
 	}
 
 	payloadJSON, err := json.Marshal(payload)
@@ -229,12 +409,35 @@ func (p *Project) SelectFunction(formID, functionID string) error {
 	}
 
 	p.Forms[formID].Request = formattedJSON
+	p.Forms[formID].RequestFields = requestFields
 	p.Forms[formID].Response = "{}"
 	p.Forms[formID].SelectedFunctionID = functionID
 
 	return p.saveState()
 }
 
+func (p *Project) FunctionSchema(functionID string) (*FunctionSchema, error) {
+	p.stateMutex.RLock()
+	defer p.stateMutex.RUnlock()
+
+	if p.serviceTree == nil {
+		return nil, fmt.Errorf("%w: %s", errThriftUnknownFunction, functionID)
+	}
+
+	return p.serviceTree.FunctionSchema(functionID)
+}
+
+func (p *Project) FileSchemas() []*FileSchema {
+	p.stateMutex.RLock()
+	defer p.stateMutex.RUnlock()
+
+	if p.serviceTree == nil {
+		return nil
+	}
+
+	return p.serviceTree.FileSchemas()
+}
+
 func (p *Project) SaveCurrentFormID(currentFormID string) error {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
@@ -262,6 +465,107 @@ func (p *Project) SaveIsMultiplexed(formID string, isMultiplexed bool) error {
 	return p.saveState()
 }
 
+func (p *Project) SaveTransport(formID string, transport Transport) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	err := validateTransport(transport)
+	if err != nil {
+		return err
+	}
+
+	p.Forms[formID].Transport = transport
+
+	return p.saveState()
+}
+
+func (p *Project) SaveProtocol(formID string, protocol Protocol) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	err := validateProtocol(protocol)
+	if err != nil {
+		return err
+	}
+
+	p.Forms[formID].Protocol = protocol
+
+	return p.saveState()
+}
+
+func (p *Project) SavePayloadMode(formID string, payloadMode PayloadMode) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	err := validatePayloadMode(payloadMode)
+	if err != nil {
+		return err
+	}
+
+	p.Forms[formID].PayloadMode = payloadMode
+
+	return p.saveState()
+}
+
+func (p *Project) SaveDebug(formID string, isDebug bool) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	p.Forms[formID].IsDebug = isDebug
+
+	return p.saveState()
+}
+
+func (p *Project) SaveHTTPSettings(formID, scheme, urlPath string, tlsSettings *tlsconfig.Settings) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	scheme, urlPath, err := normalizeHTTPSettings(scheme, urlPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = tlsSettings.Config()
+	if err != nil {
+		return err
+	}
+
+	form := p.Forms[formID]
+	form.Scheme = scheme
+	form.URLPath = urlPath
+	form.TLS = tlsSettings
+	form.ResetClient()
+
+	return p.saveState()
+}
+
+func (p *Project) SaveYARPC(formID string, yarpc *YARPC) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	form := p.Forms[formID]
+	form.YARPC = yarpc
+	form.closeTChannel()
+
+	return p.saveState()
+}
+
+func (p *Project) SaveClientSettings(formID string, clientSettings *ClientSettings) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	err := clientSettings.Validate()
+	if err != nil {
+		return err
+	}
+
+	form := p.Forms[formID]
+	form.ClientSettings = clientSettings
+	form.ResetClient()
+
+	return p.saveState()
+}
+
 func (p *Project) AddHeader(formID string) error {
 	p.stateMutex.Lock()
 	defer p.stateMutex.Unlock()
@@ -333,101 +637,318 @@ func (p *Project) BeautifyRequest(formID string) error {
 	return p.saveState()
 }
 
-func (p *Project) Close() error {
-	for _, client := range p.Forms {
-		err := client.Close()
-		if err != nil {
+func (p *Project) ClearHistory() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	p.History = nil
+
+	return p.saveState()
+}
+
+func (p *Project) DeleteHistoryEntry(entryID string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	p.History = lo.Reject(
+		p.History,
+		func(entry *HistoryEntry, _ int) bool {
+			return entry.ID == entryID
+		},
+	)
+
+	return p.saveState()
+}
+
+func (p *Project) RestoreHistoryEntry(formID, entryID string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	entry, err := p.historyEntry(entryID)
+	if err != nil {
+		return err
+	}
+
+	form := p.Forms[formID]
+	form.SelectedFunctionID = entry.FunctionID
+	form.RequestFields = p.requestFields(entry.FunctionID)
+	form.Address = entry.Address
+	form.Headers = copyHeaders(entry.Headers)
+	form.Request = entry.Request
+	form.Response = entry.Response
+
+	if form.Response == "" {
+		form.Response = "{}"
+	}
+
+	return p.saveState()
+}
+
+func (p *Project) SaveRequest(formID, name string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	form := p.Forms[formID]
+
+	p.SavedRequests = append(p.SavedRequests, &SavedRequest{
+		ID:         uuid.Must(uuid.NewV4()).String(),
+		Name:       name,
+		FunctionID: form.SelectedFunctionID,
+		Address:    form.Address,
+		Headers:    copyHeaders(form.Headers),
+		Request:    form.Request,
+	})
+
+	return p.saveState()
+}
+
+func (p *Project) DeleteSavedRequest(savedRequestID string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	p.SavedRequests = lo.Reject(
+		p.SavedRequests,
+		func(savedRequest *SavedRequest, _ int) bool {
+			return savedRequest.ID == savedRequestID
+		},
+	)
+
+	return p.saveState()
+}
+
+func (p *Project) OpenSavedRequest(formID, savedRequestID string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	savedRequest, err := p.savedRequest(savedRequestID)
+	if err != nil {
+		return err
+	}
+
+	form := p.Forms[formID]
+	form.SelectedFunctionID = savedRequest.FunctionID
+	form.RequestFields = p.requestFields(savedRequest.FunctionID)
+	form.Address = savedRequest.Address
+	form.Headers = copyHeaders(savedRequest.Headers)
+	form.Request = savedRequest.Request
+	form.Response = "{}"
+
+	return p.saveState()
+}
+
+func (p *Project) CreateEnvironment(name string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	environment := &Environment{
+		ID:   uuid.Must(uuid.NewV4()).String(),
+		Name: name,
+	}
+
+	p.Environments = append(p.Environments, environment)
+	p.CurrentEnvironmentID = environment.ID
+
+	return p.saveState()
+}
+
+func (p *Project) SaveEnvironment(environment *Environment) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	currentEnvironment, err := p.environment(environment.ID)
+	if err != nil {
+		return err
+	}
+
+	currentEnvironment.Name = environment.Name
+	currentEnvironment.Variables = environment.Variables
+
+	return p.saveState()
+}
+
+func (p *Project) DeleteEnvironment(environmentID string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	p.Environments = lo.Reject(
+		p.Environments,
+		func(environment *Environment, _ int) bool {
+			return environment.ID == environmentID
+		},
+	)
+
+	if p.CurrentEnvironmentID == environmentID {
+		p.CurrentEnvironmentID = ""
+	}
+
+	return p.saveState()
+}
+
+func (p *Project) SelectEnvironment(environmentID string) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if environmentID != "" {
+		if _, err := p.environment(environmentID); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	p.CurrentEnvironmentID = environmentID
+
+	return p.saveState()
 }
 
-func (p *Project) saveState() error {
-	err := p.stateStorage.Save(p.ID, p)
+func (p *Project) StartMockServer(
+	ctx context.Context,
+	listenAddress string,
+	transport Transport,
+	protocol Protocol,
+) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if p.MockServer == nil {
+		p.MockServer = newMockServer()
+	}
+
+	err := p.MockServer.Start(listenAddress, transport, protocol, p.serviceTree, func(call *MockCall) {
+		go p.recordMockCall(ctx, call)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to store a thrift project: %w", err)
+		return err
 	}
 
-	return nil
+	return p.saveState()
 }
 
-func (p *Project) generateNodes(filePath string) ([]*ServiceTreeNode, error) {
-	module, err := compile.Compile(filePath, compile.NonStrict())
-	if err != nil {
-		return nil, fmt.Errorf("failed compile thrift: %w", err)
+func (p *Project) StopMockServer() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if p.MockServer == nil {
+		return errMockServerNotRunning
 	}
 
-	serviceTree, err := NewServiceTree(module)
+	err := p.MockServer.Stop()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	p.serviceTree = serviceTree
+	return p.saveState()
+}
 
-	for _, form := range p.Forms {
-		form.serviceTree = serviceTree
+func (p *Project) SaveMockResponse(response *MockResponse) error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	if err := response.Validate(); err != nil {
+		return err
 	}
 
-	return serviceTree.Nodes(), nil
+	function := p.serviceTree.Function(response.FunctionID)
+	if function == nil {
+		return fmt.Errorf("%w: %s", errThriftUnknownFunction, response.FunctionID)
+	}
+
+	// an exception the function does not declare would only fail once the mock server replies
+	if _, _, err := mockResultBody(function.Spec(), response); err != nil {
+		return err
+	}
+
+	if p.MockServer == nil {
+		p.MockServer = newMockServer()
+	}
+
+	p.MockServer.SetResponse(response)
+
+	return p.saveState()
 }
 
-// nolint: funlen, cyclop
-func parseThriftType(typ compile.TypeSpec) (interface{}, error) {
-	switch spec := typ.(type) {
-	case *compile.StructSpec:
-		str := orderedmap.New[string, interface{}]()
+func (p *Project) ClearMockCalls() error {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
 
-		for _, field := range spec.Fields {
-			v, err := parseThriftType(field.Type)
-			if err != nil {
-				return nil, err
-			}
+	if p.MockServer == nil {
+		return nil
+	}
 
-			str.Set(field.Name, v)
-		}
+	p.MockServer.Calls = nil
 
-		return str, nil
-	case *compile.TypedefSpec:
-		return parseThriftType(spec.Target)
-	case *compile.StringSpec:
-		return "", nil
-	case *compile.BoolSpec:
-		return false, nil
-	case *compile.I8Spec, *compile.I16Spec, *compile.I32Spec, *compile.I64Spec, *compile.EnumSpec:
-		return 0, nil
-	case *compile.BinarySpec:
-		return []byte{}, nil
-	case *compile.DoubleSpec:
-		return 0.0, nil
-	case *compile.ListSpec:
-		v, err := parseThriftType(spec.ValueSpec)
-		if err != nil {
-			return nil, err
-		}
+	return p.saveState()
+}
 
-		return []interface{}{v}, nil
-	case *compile.SetSpec:
-		v, err := parseThriftType(spec.ValueSpec)
-		if err != nil {
-			return nil, err
+func (p *Project) Close() error {
+	if p.MockServer != nil {
+		if err := p.MockServer.Close(); err != nil {
+			return err
 		}
+	}
 
-		return []interface{}{v}, nil
-	case *compile.MapSpec:
-		key, err := parseThriftType(spec.KeySpec)
+	for _, client := range p.Forms {
+		err := client.Close()
 		if err != nil {
-			return nil, err
+			return err
 		}
+	}
 
-		value, err := parseThriftType(spec.ValueSpec)
-		if err != nil {
-			return nil, err
-		}
+	return nil
+}
+
+func (p *Project) requestFields(functionID string) []*SkeletonField {
+	if p.serviceTree == nil {
+		return nil
+	}
+
+	function := p.serviceTree.Function(functionID)
+	if function == nil {
+		return nil
+	}
+
+	_, requestFields, err := buildSkeleton(compile.FieldGroup(function.Spec().ArgsSpec))
+	if err != nil {
+		return nil
+	}
+
+	return requestFields
+}
+
+func (p *Project) recordMockCall(ctx context.Context, call *MockCall) {
+	p.stateMutex.Lock()
+	defer p.stateMutex.Unlock()
+
+	p.MockServer.Record(call)
 
-		return map[interface{}]interface{}{key: value}, nil
-	default:
-		return nil, fmt.Errorf("failed to parse %v: %w", typ, errThriftUnknownType)
+	runtime.EventsEmit(ctx, fmt.Sprintf("thrift_mock_%s", p.ID), call)
+
+	_ = p.saveState()
+}
+
+func (p *Project) saveState() error {
+	err := p.stateStorage.Save(p.ID, p)
+	if err != nil {
+		return fmt.Errorf("failed to store a thrift project: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Project) generateNodes(includePathList, fileList []string) ([]*ServiceTreeNode, error) {
+	modules, err := compileFiles(includePathList, fileList)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceTree, err := NewServiceTree(modules)
+	if err != nil {
+		return nil, err
 	}
+
+	p.serviceTree = serviceTree
+
+	if p.MockServer != nil {
+		p.MockServer.setServiceTree(serviceTree)
+	}
+
+	return serviceTree.Nodes(), nil
 }
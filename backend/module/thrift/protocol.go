@@ -0,0 +1,208 @@
+package thrift
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+)
+
+type Protocol string
+
+const (
+	ProtocolBinary  Protocol = "binary"
+	ProtocolCompact Protocol = "compact"
+	ProtocolJSON    Protocol = "json"
+)
+
+var errUnknownProtocol = errors.New("unknown thrift protocol")
+
+var applicationExceptionSpec = &compile.StructSpec{
+	Name: "TApplicationException",
+	Fields: compile.FieldGroup{
+		{ID: 1, Name: "message", Type: &compile.StringSpec{}},
+		{ID: 2, Name: "type", Type: &compile.I32Spec{}}, // nolint: gomnd
+	},
+}
+
+func validateProtocol(protocol Protocol) error {
+	switch protocol {
+	case ProtocolBinary, ProtocolCompact, ProtocolJSON:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errUnknownProtocol, protocol)
+	}
+}
+
+// transcodeRequest converts a binary encoded request built by yab into the given protocol.
+// yab always builds call envelopes, so oneway functions get their envelope type fixed here.
+func transcodeRequest(protocolName Protocol, function *compile.FunctionSpec, request []byte) ([]byte, error) {
+	if (protocolName == ProtocolBinary || protocolName == "") && !function.OneWay {
+		return request, nil
+	}
+
+	envelope, err := protocol.Binary.DecodeEnveloped(bytes.NewReader(request))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a binary request: %w", err)
+	}
+
+	if function.OneWay {
+		envelope.Type = wire.OneWay
+	}
+
+	return encodeMessage(protocolName, function, envelope)
+}
+
+// transcodeResponse converts a response in the given protocol into the binary encoding expected by yab.
+func transcodeResponse(protocolName Protocol, function *compile.FunctionSpec, response []byte) ([]byte, error) {
+	if protocolName == ProtocolBinary || protocolName == "" {
+		return response, nil
+	}
+
+	envelope, err := decodeMessage(protocolName, function, response)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := &bytes.Buffer{}
+
+	err = protocol.Binary.EncodeEnveloped(envelope, buffer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode a binary response: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func encodeMessage(protocolName Protocol, function *compile.FunctionSpec, envelope wire.Envelope) ([]byte, error) {
+	switch protocolName {
+	case ProtocolBinary, "":
+		buffer := &bytes.Buffer{}
+
+		err := protocol.Binary.EncodeEnveloped(envelope, buffer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode a binary message: %w", err)
+		}
+
+		return buffer.Bytes(), nil
+	case ProtocolCompact:
+		return encodeCompactEnvelope(envelope)
+	case ProtocolJSON:
+		return encodeJSONEnvelope(envelope, envelopeSpec(function, envelope.Type))
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownProtocol, protocolName)
+	}
+}
+
+func decodeMessage(protocolName Protocol, function *compile.FunctionSpec, message []byte) (wire.Envelope, error) {
+	switch protocolName {
+	case ProtocolBinary, "":
+		envelope, err := protocol.Binary.DecodeEnveloped(bytes.NewReader(message))
+		if err != nil {
+			return wire.Envelope{}, fmt.Errorf("failed to decode a binary message: %w", err)
+		}
+
+		return envelope, nil
+	case ProtocolCompact:
+		return decodeCompactEnvelope(bytes.NewReader(message))
+	case ProtocolJSON:
+		return decodeJSONEnvelope(message, func(envelopeType wire.EnvelopeType) compile.TypeSpec {
+			return envelopeSpec(function, envelopeType)
+		})
+	default:
+		return wire.Envelope{}, fmt.Errorf("%w: %s", errUnknownProtocol, protocolName)
+	}
+}
+
+// readMessage reads exactly one message from an unframed stream and returns its raw bytes.
+func readMessage(protocolName Protocol, reader *bufio.Reader) ([]byte, error) {
+	message := &bytes.Buffer{}
+
+	switch protocolName {
+	case ProtocolBinary, "":
+		streamReader := protocol.BinaryStreamer.Reader(io.TeeReader(reader, message))
+
+		if _, err := streamReader.ReadEnvelopeBegin(); err != nil {
+			return nil, fmt.Errorf("failed to read a thrift envelope: %w", err)
+		}
+
+		if err := streamReader.Skip(wire.TStruct); err != nil {
+			return nil, fmt.Errorf("failed to read a thrift message: %w", err)
+		}
+
+		if err := streamReader.ReadEnvelopeEnd(); err != nil {
+			return nil, fmt.Errorf("failed to read a thrift envelope: %w", err)
+		}
+	case ProtocolCompact:
+		if _, err := decodeCompactEnvelope(&teeByteReader{reader: reader, tee: message}); err != nil {
+			return nil, err
+		}
+	case ProtocolJSON:
+		var rawMessage json.RawMessage
+
+		if err := json.NewDecoder(reader).Decode(&rawMessage); err != nil {
+			return nil, fmt.Errorf("failed to read a thrift json message: %w", err)
+		}
+
+		message.Write(rawMessage)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownProtocol, protocolName)
+	}
+
+	return message.Bytes(), nil
+}
+
+func envelopeSpec(function *compile.FunctionSpec, envelopeType wire.EnvelopeType) compile.TypeSpec {
+	switch envelopeType {
+	case wire.Exception:
+		return applicationExceptionSpec
+	case wire.Call, wire.OneWay:
+		if function == nil {
+			return nil
+		}
+
+		return &compile.StructSpec{Fields: compile.FieldGroup(function.ArgsSpec)}
+	case wire.Reply:
+		if function == nil || function.ResultSpec == nil {
+			return nil
+		}
+
+		fields := compile.FieldGroup{}
+		returnType := function.ResultSpec.ReturnType
+
+		if returnType != nil {
+			fields = append(fields, &compile.FieldSpec{ID: 0, Name: "success", Type: returnType})
+		}
+
+		return &compile.StructSpec{Fields: append(fields, function.ResultSpec.Exceptions...)}
+	default:
+		return nil
+	}
+}
+
+type teeByteReader struct {
+	reader *bufio.Reader
+	tee    *bytes.Buffer
+}
+
+func (r *teeByteReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.tee.Write(p[:n])
+
+	return n, err // nolint: wrapcheck
+}
+
+func (r *teeByteReader) ReadByte() (byte, error) {
+	b, err := r.reader.ReadByte()
+	if err == nil {
+		r.tee.WriteByte(b)
+	}
+
+	return b, err // nolint: wrapcheck
+}
@@ -0,0 +1,502 @@
+package thrift
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+const (
+	compactProtocolID      = 0x82
+	compactVersion         = 1
+	compactVersionMask     = 0x1f
+	compactTypeShiftAmount = 5
+	compactTypeMask        = 0x07
+)
+
+const (
+	compactTypeStop         = 0x00
+	compactTypeBooleanTrue  = 0x01
+	compactTypeBooleanFalse = 0x02
+	compactTypeByte         = 0x03
+	compactTypeI16          = 0x04
+	compactTypeI32          = 0x05
+	compactTypeI64          = 0x06
+	compactTypeDouble       = 0x07
+	compactTypeBinary       = 0x08
+	compactTypeList         = 0x09
+	compactTypeSet          = 0x0a
+	compactTypeMap          = 0x0b
+	compactTypeStruct       = 0x0c
+)
+
+var (
+	errCompactProtocolID = errors.New("unexpected compact protocol id")
+	errCompactVersion    = errors.New("unexpected compact protocol version")
+	errCompactType       = errors.New("unknown compact protocol type")
+	errCompactSize       = errors.New("invalid compact protocol size")
+)
+
+type compactReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func encodeCompactEnvelope(envelope wire.Envelope) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+
+	buffer.WriteByte(compactProtocolID)
+	buffer.WriteByte(compactVersion | byte(envelope.Type)<<compactTypeShiftAmount)
+	writeCompactUvarint(buffer, uint64(uint32(envelope.SeqID)))
+	writeCompactBinary(buffer, []byte(envelope.Name))
+
+	if err := writeCompactValue(buffer, envelope.Value); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func decodeCompactEnvelope(reader compactReader) (wire.Envelope, error) {
+	protocolID, err := reader.ReadByte()
+	if err != nil {
+		return wire.Envelope{}, fmt.Errorf("failed to read a compact protocol id: %w", err)
+	}
+
+	if protocolID != compactProtocolID {
+		return wire.Envelope{}, fmt.Errorf("%w: %#x", errCompactProtocolID, protocolID)
+	}
+
+	versionAndType, err := reader.ReadByte()
+	if err != nil {
+		return wire.Envelope{}, fmt.Errorf("failed to read a compact protocol version: %w", err)
+	}
+
+	if versionAndType&compactVersionMask != compactVersion {
+		return wire.Envelope{}, fmt.Errorf("%w: %d", errCompactVersion, versionAndType&compactVersionMask)
+	}
+
+	seqID, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return wire.Envelope{}, fmt.Errorf("failed to read a compact sequence id: %w", err)
+	}
+
+	name, err := readCompactBinary(reader)
+	if err != nil {
+		return wire.Envelope{}, err
+	}
+
+	value, err := readCompactValue(reader, compactTypeStruct)
+	if err != nil {
+		return wire.Envelope{}, err
+	}
+
+	return wire.Envelope{
+		Name:  string(name),
+		Type:  wire.EnvelopeType((versionAndType >> compactTypeShiftAmount) & compactTypeMask),
+		SeqID: int32(seqID),
+		Value: value,
+	}, nil
+}
+
+// nolint: cyclop
+func writeCompactValue(buffer *bytes.Buffer, value wire.Value) error {
+	switch value.Type() {
+	case wire.TBool:
+		if value.GetBool() {
+			buffer.WriteByte(compactTypeBooleanTrue)
+		} else {
+			buffer.WriteByte(compactTypeBooleanFalse)
+		}
+	case wire.TI8:
+		buffer.WriteByte(byte(value.GetI8()))
+	case wire.TI16:
+		writeCompactVarint(buffer, int64(value.GetI16()))
+	case wire.TI32:
+		writeCompactVarint(buffer, int64(value.GetI32()))
+	case wire.TI64:
+		writeCompactVarint(buffer, value.GetI64())
+	case wire.TDouble:
+		doubleBytes := make([]byte, 8) // nolint: gomnd
+		binary.LittleEndian.PutUint64(doubleBytes, math.Float64bits(value.GetDouble()))
+		buffer.Write(doubleBytes)
+	case wire.TBinary:
+		writeCompactBinary(buffer, value.GetBinary())
+	case wire.TStruct:
+		return writeCompactStruct(buffer, value.GetStruct())
+	case wire.TList:
+		return writeCompactList(buffer, value.GetList())
+	case wire.TSet:
+		return writeCompactList(buffer, value.GetSet())
+	case wire.TMap:
+		return writeCompactMap(buffer, value.GetMap())
+	default:
+		return fmt.Errorf("%w: %v", errCompactType, value.Type())
+	}
+
+	return nil
+}
+
+func writeCompactStruct(buffer *bytes.Buffer, value wire.Struct) error {
+	var lastFieldID int16
+
+	for _, field := range value.Fields {
+		compactType := compactTypeFromWire(field.Value.Type())
+
+		if field.Value.Type() == wire.TBool && !field.Value.GetBool() {
+			compactType = compactTypeBooleanFalse
+		}
+
+		delta := field.ID - lastFieldID
+		if delta > 0 && delta <= 15 {
+			buffer.WriteByte(byte(delta)<<4 | compactType) // nolint: gomnd
+		} else {
+			buffer.WriteByte(compactType)
+			writeCompactVarint(buffer, int64(field.ID))
+		}
+
+		lastFieldID = field.ID
+
+		if field.Value.Type() == wire.TBool {
+			continue
+		}
+
+		if err := writeCompactValue(buffer, field.Value); err != nil {
+			return err
+		}
+	}
+
+	buffer.WriteByte(compactTypeStop)
+
+	return nil
+}
+
+func writeCompactList(buffer *bytes.Buffer, list wire.ValueList) error {
+	elementType := compactTypeFromWire(list.ValueType())
+
+	if list.Size() < 15 { // nolint: gomnd
+		buffer.WriteByte(byte(list.Size())<<4 | elementType) // nolint: gomnd
+	} else {
+		buffer.WriteByte(0xf0 | elementType) // nolint: gomnd
+		writeCompactUvarint(buffer, uint64(list.Size()))
+	}
+
+	err := list.ForEach(func(value wire.Value) error {
+		return writeCompactValue(buffer, value)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write a compact list: %w", err)
+	}
+
+	return nil
+}
+
+func writeCompactMap(buffer *bytes.Buffer, items wire.MapItemList) error {
+	if items.Size() == 0 {
+		buffer.WriteByte(0)
+
+		return nil
+	}
+
+	writeCompactUvarint(buffer, uint64(items.Size()))
+	keyType, valueType := compactTypeFromWire(items.KeyType()), compactTypeFromWire(items.ValueType())
+	buffer.WriteByte(keyType<<4 | valueType) // nolint: gomnd
+
+	err := items.ForEach(func(item wire.MapItem) error {
+		if err := writeCompactValue(buffer, item.Key); err != nil {
+			return err
+		}
+
+		return writeCompactValue(buffer, item.Value)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write a compact map: %w", err)
+	}
+
+	return nil
+}
+
+func writeCompactBinary(buffer *bytes.Buffer, value []byte) {
+	writeCompactUvarint(buffer, uint64(len(value)))
+	buffer.Write(value)
+}
+
+func writeCompactVarint(buffer *bytes.Buffer, value int64) {
+	writeCompactUvarint(buffer, uint64((value<<1)^(value>>63))) // nolint: gomnd
+}
+
+func writeCompactUvarint(buffer *bytes.Buffer, value uint64) {
+	varintBytes := make([]byte, binary.MaxVarintLen64)
+	buffer.Write(varintBytes[:binary.PutUvarint(varintBytes, value)])
+}
+
+// nolint: cyclop, funlen
+func readCompactValue(reader compactReader, compactType byte) (wire.Value, error) {
+	switch compactType {
+	case compactTypeBooleanTrue, compactTypeBooleanFalse:
+		value, err := reader.ReadByte()
+		if err != nil {
+			return wire.Value{}, fmt.Errorf("failed to read a compact bool: %w", err)
+		}
+
+		return wire.NewValueBool(value == compactTypeBooleanTrue), nil
+	case compactTypeByte:
+		value, err := reader.ReadByte()
+		if err != nil {
+			return wire.Value{}, fmt.Errorf("failed to read a compact byte: %w", err)
+		}
+
+		return wire.NewValueI8(int8(value)), nil
+	case compactTypeI16:
+		value, err := readCompactVarint(reader)
+
+		return wire.NewValueI16(int16(value)), err
+	case compactTypeI32:
+		value, err := readCompactVarint(reader)
+
+		return wire.NewValueI32(int32(value)), err
+	case compactTypeI64:
+		value, err := readCompactVarint(reader)
+
+		return wire.NewValueI64(value), err
+	case compactTypeDouble:
+		doubleBytes := make([]byte, 8) // nolint: gomnd
+
+		if _, err := io.ReadFull(reader, doubleBytes); err != nil {
+			return wire.Value{}, fmt.Errorf("failed to read a compact double: %w", err)
+		}
+
+		return wire.NewValueDouble(math.Float64frombits(binary.LittleEndian.Uint64(doubleBytes))), nil
+	case compactTypeBinary:
+		value, err := readCompactBinary(reader)
+
+		return wire.NewValueBinary(value), err
+	case compactTypeStruct:
+		value, err := readCompactStruct(reader)
+
+		return wire.NewValueStruct(value), err
+	case compactTypeList:
+		value, err := readCompactList(reader)
+
+		return wire.NewValueList(value), err
+	case compactTypeSet:
+		value, err := readCompactList(reader)
+
+		return wire.NewValueSet(value), err
+	case compactTypeMap:
+		value, err := readCompactMap(reader)
+
+		return wire.NewValueMap(value), err
+	default:
+		return wire.Value{}, fmt.Errorf("%w: %#x", errCompactType, compactType)
+	}
+}
+
+func readCompactStruct(reader compactReader) (wire.Struct, error) {
+	var (
+		fields      []wire.Field
+		lastFieldID int16
+	)
+
+	for {
+		fieldHeader, err := reader.ReadByte()
+		if err != nil {
+			return wire.Struct{}, fmt.Errorf("failed to read a compact field header: %w", err)
+		}
+
+		if fieldHeader == compactTypeStop {
+			return wire.Struct{Fields: fields}, nil
+		}
+
+		compactType := fieldHeader & 0x0f              // nolint: gomnd
+		fieldID := lastFieldID + int16(fieldHeader>>4) // nolint: gomnd
+
+		if fieldHeader>>4 == 0 { // nolint: gomnd
+			id, err := readCompactVarint(reader)
+			if err != nil {
+				return wire.Struct{}, err
+			}
+
+			fieldID = int16(id)
+		}
+
+		lastFieldID = fieldID
+
+		var value wire.Value
+
+		if compactType == compactTypeBooleanTrue || compactType == compactTypeBooleanFalse {
+			value = wire.NewValueBool(compactType == compactTypeBooleanTrue)
+		} else {
+			value, err = readCompactValue(reader, compactType)
+			if err != nil {
+				return wire.Struct{}, err
+			}
+		}
+
+		fields = append(fields, wire.Field{ID: fieldID, Value: value})
+	}
+}
+
+func readCompactList(reader compactReader) (wire.ValueList, error) {
+	listHeader, err := reader.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read a compact list header: %w", err)
+	}
+
+	elementType := listHeader & 0x0f // nolint: gomnd
+	size := uint64(listHeader >> 4)  // nolint: gomnd
+
+	if size == 15 { // nolint: gomnd
+		size, err = binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read a compact list size: %w", err)
+		}
+	}
+
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("%w: %d", errCompactSize, size)
+	}
+
+	values := make([]wire.Value, 0, size)
+
+	for i := uint64(0); i < size; i++ {
+		value, err := readCompactValue(reader, elementType)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return wire.ValueListFromSlice(wireTypeFromCompact(elementType), values), nil
+}
+
+func readCompactMap(reader compactReader) (wire.MapItemList, error) {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read a compact map size: %w", err)
+	}
+
+	if size == 0 {
+		return wire.MapItemListFromSlice(wire.TBinary, wire.TBinary, nil), nil
+	}
+
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("%w: %d", errCompactSize, size)
+	}
+
+	types, err := reader.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compact map types: %w", err)
+	}
+
+	keyType, valueType := types>>4, types&0x0f // nolint: gomnd
+	items := make([]wire.MapItem, 0, size)
+
+	for i := uint64(0); i < size; i++ {
+		key, err := readCompactValue(reader, keyType)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := readCompactValue(reader, valueType)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, wire.MapItem{Key: key, Value: value})
+	}
+
+	return wire.MapItemListFromSlice(wireTypeFromCompact(keyType), wireTypeFromCompact(valueType), items), nil
+}
+
+func readCompactBinary(reader compactReader) ([]byte, error) {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read a compact binary size: %w", err)
+	}
+
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("%w: %d", errCompactSize, size)
+	}
+
+	value := make([]byte, size)
+
+	if _, err := io.ReadFull(reader, value); err != nil {
+		return nil, fmt.Errorf("failed to read a compact binary: %w", err)
+	}
+
+	return value, nil
+}
+
+func readCompactVarint(reader compactReader) (int64, error) {
+	value, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read a compact varint: %w", err)
+	}
+
+	return int64(value>>1) ^ -int64(value&1), nil
+}
+
+// nolint: cyclop
+func compactTypeFromWire(wireType wire.Type) byte {
+	switch wireType {
+	case wire.TBool:
+		return compactTypeBooleanTrue
+	case wire.TI8:
+		return compactTypeByte
+	case wire.TI16:
+		return compactTypeI16
+	case wire.TI32:
+		return compactTypeI32
+	case wire.TI64:
+		return compactTypeI64
+	case wire.TDouble:
+		return compactTypeDouble
+	case wire.TBinary:
+		return compactTypeBinary
+	case wire.TList:
+		return compactTypeList
+	case wire.TSet:
+		return compactTypeSet
+	case wire.TMap:
+		return compactTypeMap
+	case wire.TStruct:
+		return compactTypeStruct
+	default:
+		return compactTypeStop
+	}
+}
+
+// nolint: cyclop
+func wireTypeFromCompact(compactType byte) wire.Type {
+	switch compactType {
+	case compactTypeBooleanTrue, compactTypeBooleanFalse:
+		return wire.TBool
+	case compactTypeByte:
+		return wire.TI8
+	case compactTypeI16:
+		return wire.TI16
+	case compactTypeI32:
+		return wire.TI32
+	case compactTypeI64:
+		return wire.TI64
+	case compactTypeDouble:
+		return wire.TDouble
+	case compactTypeList:
+		return wire.TList
+	case compactTypeSet:
+		return wire.TSet
+	case compactTypeMap:
+		return wire.TMap
+	case compactTypeStruct:
+		return wire.TStruct
+	default:
+		return wire.TBinary
+	}
+}
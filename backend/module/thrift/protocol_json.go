@@ -0,0 +1,584 @@
+package thrift
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/wire"
+)
+
+const jsonProtocolVersion = 1
+
+const (
+	jsonTypeBool   = "tf"
+	jsonTypeI8     = "i8"
+	jsonTypeI16    = "i16"
+	jsonTypeI32    = "i32"
+	jsonTypeI64    = "i64"
+	jsonTypeDouble = "dbl"
+	jsonTypeString = "str"
+	jsonTypeStruct = "rec"
+	jsonTypeMap    = "map"
+	jsonTypeSet    = "set"
+	jsonTypeList   = "lst"
+)
+
+var (
+	errJSONMessage = errors.New("malformed thrift json message")
+	errJSONType    = errors.New("unknown thrift json type")
+)
+
+func encodeJSONEnvelope(envelope wire.Envelope, spec compile.TypeSpec) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+
+	name, err := json.Marshal(envelope.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal a message name: %w", err)
+	}
+
+	fmt.Fprintf(buffer, "[%d,%s,%d,%d,", jsonProtocolVersion, name, envelope.Type, envelope.SeqID)
+
+	if err := writeJSONValue(buffer, envelope.Value, spec, false); err != nil {
+		return nil, err
+	}
+
+	buffer.WriteByte(']')
+
+	return buffer.Bytes(), nil
+}
+
+// nolint: cyclop
+func decodeJSONEnvelope(
+	message []byte,
+	specByType func(envelopeType wire.EnvelopeType) compile.TypeSpec,
+) (wire.Envelope, error) {
+	decoder := json.NewDecoder(bytes.NewReader(message))
+	decoder.UseNumber()
+
+	var parts []interface{}
+
+	if err := decoder.Decode(&parts); err != nil {
+		return wire.Envelope{}, fmt.Errorf("failed to decode a thrift json message: %w", err)
+	}
+
+	if len(parts) != 5 { // nolint: gomnd
+		return wire.Envelope{}, fmt.Errorf("%w: expected 5 elements, got %d", errJSONMessage, len(parts))
+	}
+
+	version, err := jsonInt(parts[0])
+	if err != nil || version != jsonProtocolVersion {
+		return wire.Envelope{}, fmt.Errorf("%w: unexpected version %v", errJSONMessage, parts[0])
+	}
+
+	name, ok := parts[1].(string)
+	if !ok {
+		return wire.Envelope{}, fmt.Errorf("%w: unexpected name %v", errJSONMessage, parts[1])
+	}
+
+	envelopeType, err := jsonInt(parts[2])
+	if err != nil {
+		return wire.Envelope{}, err
+	}
+
+	seqID, err := jsonInt(parts[3])
+	if err != nil {
+		return wire.Envelope{}, err
+	}
+
+	value, err := readJSONValue(parts[4], jsonTypeStruct, specByType(wire.EnvelopeType(envelopeType)))
+	if err != nil {
+		return wire.Envelope{}, err
+	}
+
+	return wire.Envelope{
+		Name:  name,
+		Type:  wire.EnvelopeType(envelopeType),
+		SeqID: int32(seqID),
+		Value: value,
+	}, nil
+}
+
+// nolint: cyclop, funlen
+func writeJSONValue(buffer *bytes.Buffer, value wire.Value, spec compile.TypeSpec, isMapKey bool) error {
+	quote := func(text string) {
+		if isMapKey {
+			buffer.WriteString(strconv.Quote(text))
+		} else {
+			buffer.WriteString(text)
+		}
+	}
+
+	switch value.Type() {
+	case wire.TBool:
+		if value.GetBool() {
+			quote("1")
+		} else {
+			quote("0")
+		}
+	case wire.TI8:
+		quote(strconv.FormatInt(int64(value.GetI8()), 10))
+	case wire.TI16:
+		quote(strconv.FormatInt(int64(value.GetI16()), 10))
+	case wire.TI32:
+		quote(strconv.FormatInt(int64(value.GetI32()), 10))
+	case wire.TI64:
+		quote(strconv.FormatInt(value.GetI64(), 10))
+	case wire.TDouble:
+		double := value.GetDouble()
+
+		switch {
+		case math.IsNaN(double):
+			buffer.WriteString(`"NaN"`)
+		case math.IsInf(double, 1):
+			buffer.WriteString(`"Infinity"`)
+		case math.IsInf(double, -1):
+			buffer.WriteString(`"-Infinity"`)
+		default:
+			quote(strconv.FormatFloat(double, 'g', -1, 64))
+		}
+	case wire.TBinary:
+		if isBinarySpec(spec) {
+			buffer.WriteString(strconv.Quote(base64.StdEncoding.EncodeToString(value.GetBinary())))
+		} else {
+			text, err := json.Marshal(value.GetString())
+			if err != nil {
+				return fmt.Errorf("failed to marshal a string: %w", err)
+			}
+
+			buffer.Write(text)
+		}
+	case wire.TStruct:
+		if isMapKey {
+			return writeJSONMapKeyValue(buffer, value, spec)
+		}
+
+		return writeJSONStruct(buffer, value.GetStruct(), spec)
+	case wire.TList, wire.TSet, wire.TMap:
+		if isMapKey {
+			return writeJSONMapKeyValue(buffer, value, spec)
+		}
+
+		return writeJSONContainer(buffer, value, spec)
+	default:
+		return fmt.Errorf("%w: %v", errJSONType, value.Type())
+	}
+
+	return nil
+}
+
+func writeJSONStruct(buffer *bytes.Buffer, value wire.Struct, spec compile.TypeSpec) error {
+	buffer.WriteByte('{')
+
+	for i, field := range value.Fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		fmt.Fprintf(buffer, `"%d":{"%s":`, field.ID, jsonTypeName(field.Value.Type()))
+
+		if err := writeJSONValue(buffer, field.Value, fieldSpecByID(spec, field.ID), false); err != nil {
+			return err
+		}
+
+		buffer.WriteByte('}')
+	}
+
+	buffer.WriteByte('}')
+
+	return nil
+}
+
+func writeJSONContainer(buffer *bytes.Buffer, value wire.Value, spec compile.TypeSpec) error {
+	keySpec, valueSpec := containerSpecs(spec)
+
+	var err error
+
+	switch value.Type() { // nolint: exhaustive
+	case wire.TList, wire.TSet:
+		list := value.GetList()
+		if value.Type() == wire.TSet {
+			list = value.GetSet()
+		}
+
+		fmt.Fprintf(buffer, `["%s",%d`, jsonTypeName(list.ValueType()), list.Size())
+
+		err = list.ForEach(func(item wire.Value) error {
+			buffer.WriteByte(',')
+
+			return writeJSONValue(buffer, item, valueSpec, false)
+		})
+	case wire.TMap:
+		items := value.GetMap()
+
+		fmt.Fprintf(
+			buffer,
+			`["%s","%s",%d,{`,
+			jsonTypeName(items.KeyType()),
+			jsonTypeName(items.ValueType()),
+			items.Size(),
+		)
+
+		isFirst := true
+
+		err = items.ForEach(func(item wire.MapItem) error {
+			if !isFirst {
+				buffer.WriteByte(',')
+			}
+
+			isFirst = false
+
+			if err := writeJSONValue(buffer, item.Key, keySpec, true); err != nil {
+				return err
+			}
+
+			buffer.WriteByte(':')
+
+			return writeJSONValue(buffer, item.Value, valueSpec, false)
+		})
+
+		buffer.WriteByte('}')
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write a thrift json container: %w", err)
+	}
+
+	buffer.WriteByte(']')
+
+	return nil
+}
+
+func writeJSONMapKeyValue(buffer *bytes.Buffer, value wire.Value, spec compile.TypeSpec) error {
+	keyBuffer := &bytes.Buffer{}
+
+	if err := writeJSONValue(keyBuffer, value, spec, false); err != nil {
+		return err
+	}
+
+	buffer.WriteString(strconv.Quote(keyBuffer.String()))
+
+	return nil
+}
+
+// nolint: cyclop, funlen, gocognit
+func readJSONValue(raw interface{}, typeName string, spec compile.TypeSpec) (wire.Value, error) {
+	switch typeName {
+	case jsonTypeBool:
+		value, err := jsonInt(raw)
+
+		return wire.NewValueBool(value != 0), err
+	case jsonTypeI8:
+		value, err := jsonInt(raw)
+
+		return wire.NewValueI8(int8(value)), err
+	case jsonTypeI16:
+		value, err := jsonInt(raw)
+
+		return wire.NewValueI16(int16(value)), err
+	case jsonTypeI32:
+		value, err := jsonInt(raw)
+
+		return wire.NewValueI32(int32(value)), err
+	case jsonTypeI64:
+		value, err := jsonInt(raw)
+
+		return wire.NewValueI64(value), err
+	case jsonTypeDouble:
+		value, err := jsonDouble(raw)
+
+		return wire.NewValueDouble(value), err
+	case jsonTypeString:
+		text, ok := raw.(string)
+		if !ok {
+			return wire.Value{}, fmt.Errorf("%w: expected a string, got %v", errJSONMessage, raw)
+		}
+
+		if !isBinarySpec(spec) {
+			return wire.NewValueString(text), nil
+		}
+
+		value, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			value, err = base64.RawStdEncoding.DecodeString(text)
+			if err != nil {
+				return wire.Value{}, fmt.Errorf("failed to decode a base64 binary: %w", err)
+			}
+		}
+
+		return wire.NewValueBinary(value), nil
+	case jsonTypeStruct:
+		object, ok := raw.(map[string]interface{})
+		if !ok {
+			return wire.Value{}, fmt.Errorf("%w: expected an object, got %v", errJSONMessage, raw)
+		}
+
+		fields := make([]wire.Field, 0, len(object))
+
+		for key, rawField := range object {
+			fieldID, err := strconv.ParseInt(key, 10, 16)
+			if err != nil {
+				return wire.Value{}, fmt.Errorf("%w: unexpected field id %s", errJSONMessage, key)
+			}
+
+			fieldObject, ok := rawField.(map[string]interface{})
+			if !ok || len(fieldObject) != 1 {
+				return wire.Value{}, fmt.Errorf("%w: unexpected field %v", errJSONMessage, rawField)
+			}
+
+			for fieldType, rawValue := range fieldObject {
+				value, err := readJSONValue(rawValue, fieldType, fieldSpecByID(spec, int16(fieldID)))
+				if err != nil {
+					return wire.Value{}, err
+				}
+
+				fields = append(fields, wire.Field{ID: int16(fieldID), Value: value})
+			}
+		}
+
+		sort.Slice(fields, func(i, j int) bool {
+			return fields[i].ID < fields[j].ID
+		})
+
+		return wire.NewValueStruct(wire.Struct{Fields: fields}), nil
+	case jsonTypeList, jsonTypeSet:
+		list, ok := raw.([]interface{})
+		if !ok || len(list) < 2 {
+			return wire.Value{}, fmt.Errorf("%w: unexpected list %v", errJSONMessage, raw)
+		}
+
+		elementType, ok := list[0].(string)
+		if !ok {
+			return wire.Value{}, fmt.Errorf("%w: unexpected list type %v", errJSONMessage, list[0])
+		}
+
+		_, valueSpec := containerSpecs(spec)
+		values := make([]wire.Value, 0, len(list)-2)
+
+		for _, rawValue := range list[2:] {
+			value, err := readJSONValue(rawValue, elementType, valueSpec)
+			if err != nil {
+				return wire.Value{}, err
+			}
+
+			values = append(values, value)
+		}
+
+		valueList := wire.ValueListFromSlice(wireTypeFromJSON(elementType), values)
+
+		if typeName == jsonTypeSet {
+			return wire.NewValueSet(valueList), nil
+		}
+
+		return wire.NewValueList(valueList), nil
+	case jsonTypeMap:
+		return readJSONMap(raw, spec)
+	default:
+		return wire.Value{}, fmt.Errorf("%w: %s", errJSONType, typeName)
+	}
+}
+
+// nolint: cyclop
+func readJSONMap(raw interface{}, spec compile.TypeSpec) (wire.Value, error) {
+	list, ok := raw.([]interface{})
+	if !ok || len(list) != 4 { // nolint: gomnd
+		return wire.Value{}, fmt.Errorf("%w: unexpected map %v", errJSONMessage, raw)
+	}
+
+	keyType, isKeyTypeOK := list[0].(string)
+	valueType, isValueTypeOK := list[1].(string)
+	object, isObjectOK := list[3].(map[string]interface{})
+
+	if !isKeyTypeOK || !isValueTypeOK || !isObjectOK {
+		return wire.Value{}, fmt.Errorf("%w: unexpected map %v", errJSONMessage, raw)
+	}
+
+	keySpec, valueSpec := containerSpecs(spec)
+	items := make([]wire.MapItem, 0, len(object))
+
+	for rawKey, rawValue := range object {
+		var keyRaw interface{} = rawKey
+
+		switch keyType {
+		case jsonTypeString:
+		case jsonTypeStruct, jsonTypeMap, jsonTypeList, jsonTypeSet:
+			decoder := json.NewDecoder(bytes.NewReader([]byte(rawKey)))
+			decoder.UseNumber()
+
+			if err := decoder.Decode(&keyRaw); err != nil {
+				return wire.Value{}, fmt.Errorf("failed to decode a thrift json map key: %w", err)
+			}
+		default:
+			keyRaw = json.Number(rawKey)
+		}
+
+		key, err := readJSONValue(keyRaw, keyType, keySpec)
+		if err != nil {
+			return wire.Value{}, err
+		}
+
+		value, err := readJSONValue(rawValue, valueType, valueSpec)
+		if err != nil {
+			return wire.Value{}, err
+		}
+
+		items = append(items, wire.MapItem{Key: key, Value: value})
+	}
+
+	return wire.NewValueMap(
+		wire.MapItemListFromSlice(wireTypeFromJSON(keyType), wireTypeFromJSON(valueType), items),
+	), nil
+}
+
+func jsonInt(raw interface{}) (int64, error) {
+	switch value := raw.(type) {
+	case json.Number:
+		number, err := value.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("%w: unexpected number %s", errJSONMessage, value)
+		}
+
+		return number, nil
+	case string:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: unexpected number %s", errJSONMessage, value)
+		}
+
+		return number, nil
+	default:
+		return 0, fmt.Errorf("%w: unexpected number %v", errJSONMessage, raw)
+	}
+}
+
+func jsonDouble(raw interface{}) (float64, error) {
+	switch value := raw.(type) {
+	case json.Number:
+		number, err := value.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("%w: unexpected double %s", errJSONMessage, value)
+		}
+
+		return number, nil
+	case string:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: unexpected double %s", errJSONMessage, value)
+		}
+
+		return number, nil
+	default:
+		return 0, fmt.Errorf("%w: unexpected double %v", errJSONMessage, raw)
+	}
+}
+
+// nolint: cyclop
+func jsonTypeName(wireType wire.Type) string {
+	switch wireType {
+	case wire.TBool:
+		return jsonTypeBool
+	case wire.TI8:
+		return jsonTypeI8
+	case wire.TI16:
+		return jsonTypeI16
+	case wire.TI32:
+		return jsonTypeI32
+	case wire.TI64:
+		return jsonTypeI64
+	case wire.TDouble:
+		return jsonTypeDouble
+	case wire.TBinary:
+		return jsonTypeString
+	case wire.TStruct:
+		return jsonTypeStruct
+	case wire.TMap:
+		return jsonTypeMap
+	case wire.TSet:
+		return jsonTypeSet
+	case wire.TList:
+		return jsonTypeList
+	default:
+		return ""
+	}
+}
+
+// nolint: cyclop
+func wireTypeFromJSON(typeName string) wire.Type {
+	switch typeName {
+	case jsonTypeBool:
+		return wire.TBool
+	case jsonTypeI8:
+		return wire.TI8
+	case jsonTypeI16:
+		return wire.TI16
+	case jsonTypeI32:
+		return wire.TI32
+	case jsonTypeI64:
+		return wire.TI64
+	case jsonTypeDouble:
+		return wire.TDouble
+	case jsonTypeStruct:
+		return wire.TStruct
+	case jsonTypeMap:
+		return wire.TMap
+	case jsonTypeSet:
+		return wire.TSet
+	case jsonTypeList:
+		return wire.TList
+	default:
+		return wire.TBinary
+	}
+}
+
+func isBinarySpec(spec compile.TypeSpec) bool {
+	if spec == nil {
+		return false
+	}
+
+	_, ok := compile.RootTypeSpec(spec).(*compile.BinarySpec)
+
+	return ok
+}
+
+func fieldSpecByID(spec compile.TypeSpec, fieldID int16) compile.TypeSpec {
+	if spec == nil {
+		return nil
+	}
+
+	structSpec, ok := compile.RootTypeSpec(spec).(*compile.StructSpec)
+	if !ok {
+		return nil
+	}
+
+	for _, field := range structSpec.Fields {
+		if field.ID == fieldID {
+			return field.Type
+		}
+	}
+
+	return nil
+}
+
+// nolint: ireturn, nonamedreturns
+func containerSpecs(spec compile.TypeSpec) (keySpec, valueSpec compile.TypeSpec) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	switch containerSpec := compile.RootTypeSpec(spec).(type) {
+	case *compile.ListSpec:
+		return nil, containerSpec.ValueSpec
+	case *compile.SetSpec:
+		return nil, containerSpec.ValueSpec
+	case *compile.MapSpec:
+		return containerSpec.KeySpec, containerSpec.ValueSpec
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,202 @@
+package thrift
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/uber/tchannel-go"
+	"github.com/yarpc/yab/thrift"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+)
+
+type ResponseStatus string
+
+const (
+	ResponseStatusSuccess              ResponseStatus = "success"
+	ResponseStatusOneWay               ResponseStatus = "oneway"
+	ResponseStatusException            ResponseStatus = "exception"
+	ResponseStatusApplicationException ResponseStatus = "applicationException"
+	ResponseStatusTransportException   ResponseStatus = "transportException"
+)
+
+// nolint: gochecknoglobals
+var (
+	applicationExceptionTypeNames = map[int32]string{
+		0:  "UNKNOWN",
+		1:  "UNKNOWN_METHOD",
+		2:  "INVALID_MESSAGE_TYPE",
+		3:  "WRONG_METHOD_NAME",
+		4:  "BAD_SEQUENCE_ID",
+		5:  "MISSING_RESULT",
+		6:  "INTERNAL_ERROR",
+		7:  "PROTOCOL_ERROR",
+		8:  "INVALID_TRANSFORM",
+		9:  "INVALID_PROTOCOL",
+		10: "UNSUPPORTED_CLIENT_TYPE",
+	}
+	transportExceptionTypeNames = map[int32]string{
+		0: "UNKNOWN",
+		1: "NOT_OPEN",
+		2: "ALREADY_OPEN",
+		3: "TIMED_OUT",
+		4: "END_OF_FILE",
+	}
+)
+
+const (
+	transportExceptionUnknown   = 0
+	transportExceptionNotOpen   = 1
+	transportExceptionTimedOut  = 3
+	transportExceptionEndOfFile = 4
+)
+
+var errUnexpectedHTTPStatus = errors.New("unexpected http status")
+
+type ResponseJSON struct {
+	Status               ResponseStatus             `json:"status"`
+	Result               interface{}                `json:"result,omitempty"`
+	Exception            *ResponseJSONException     `json:"exception,omitempty"`
+	ApplicationException *ResponseJSONErrorWithType `json:"applicationException,omitempty"`
+	TransportException   *ResponseJSONErrorWithType `json:"transportException,omitempty"`
+	Headers              map[string]string          `json:"headers,omitempty"`
+	HTTP                 *ResponseHTTP              `json:"http,omitempty"`
+	Debug                *ResponseDebug             `json:"debug,omitempty"`
+	Error                string                     `json:"error,omitempty"`
+}
+
+type ResponseJSONException struct {
+	Name   string      `json:"name"`
+	Type   string      `json:"type"`
+	Fields interface{} `json:"fields"`
+}
+
+type ResponseJSONErrorWithType struct {
+	Type     int32  `json:"type"`
+	TypeName string `json:"typeName"`
+	Message  string `json:"message"`
+}
+
+type ResponseHTTP struct {
+	StatusCode int                 `json:"statusCode"`
+	Headers    map[string][]string `json:"headers"`
+}
+
+type ResponseDebug struct {
+	Request  *WireDump `json:"request"`
+	Response *WireDump `json:"response,omitempty"`
+}
+
+func newTransportExceptionResponse(err error, httpResponse *ResponseHTTP) *ResponseJSON {
+	exceptionType := int32(transportExceptionUnknown)
+
+	var netErr net.Error
+
+	var opErr *net.OpError
+
+	var systemErr tchannel.SystemError
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout(),
+		errors.As(err, &systemErr) && systemErr.Code() == tchannel.ErrCodeTimeout:
+		exceptionType = transportExceptionTimedOut
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		exceptionType = transportExceptionNotOpen
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		exceptionType = transportExceptionEndOfFile
+	}
+
+	return &ResponseJSON{
+		Status: ResponseStatusTransportException,
+		TransportException: &ResponseJSONErrorWithType{
+			Type:     exceptionType,
+			TypeName: transportExceptionTypeNames[exceptionType],
+			Message:  err.Error(),
+		},
+		HTTP: httpResponse,
+	}
+}
+
+// parseResponse converts a binary encoded reply into a response, telling apart results,
+// declared exceptions and application exceptions.
+func parseResponse(function *compile.FunctionSpec, binaryResponse []byte) (*ResponseJSON, error) {
+	envelope, err := protocol.Binary.DecodeEnveloped(bytes.NewReader(binaryResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse thrift response: %w", err)
+	}
+
+	if envelope.Type == wire.Exception {
+		return parseApplicationException(envelope.Value), nil
+	}
+
+	thriftResponse, err := thrift.ResponseBytesToMap(
+		function,
+		binaryResponse,
+		thrift.Options{UseEnvelopes: true},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse thrift response: %w", err)
+	}
+
+	if function.ResultSpec != nil {
+		for _, exceptionSpec := range function.ResultSpec.Exceptions {
+			fields, ok := thriftResponse[exceptionSpec.Name]
+			if !ok {
+				continue
+			}
+
+			return &ResponseJSON{
+				Status: ResponseStatusException,
+				Exception: &ResponseJSONException{
+					Name:   exceptionSpec.Name,
+					Type:   exceptionSpec.Type.ThriftName(),
+					Fields: fields,
+				},
+			}, nil
+		}
+	}
+
+	return &ResponseJSON{
+		Status: ResponseStatusSuccess,
+		Result: thriftResponse["result"],
+	}, nil
+}
+
+func parseApplicationException(value wire.Value) *ResponseJSON {
+	applicationException := &ResponseJSONErrorWithType{}
+
+	if value.Type() == wire.TStruct {
+		for _, field := range value.GetStruct().Fields {
+			switch {
+			case field.ID == 1 && field.Value.Type() == wire.TBinary:
+				applicationException.Message = field.Value.GetString()
+			case field.ID == 2 && field.Value.Type() == wire.TI32: // nolint: gomnd
+				applicationException.Type = field.Value.GetI32()
+			}
+		}
+	}
+
+	applicationException.TypeName = applicationExceptionTypeNames[applicationException.Type]
+	if applicationException.TypeName == "" {
+		applicationException.TypeName = applicationExceptionTypeNames[0]
+	}
+
+	return &ResponseJSON{
+		Status:               ResponseStatusApplicationException,
+		ApplicationException: applicationException,
+	}
+}
+
+func newResponseHTTP(response *http.Response) *ResponseHTTP {
+	return &ResponseHTTP{
+		StatusCode: response.StatusCode,
+		Headers:    response.Header,
+	}
+}
@@ -0,0 +1,256 @@
+package thrift
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/idl"
+)
+
+type FunctionSchema struct {
+	ServiceName string            `json:"serviceName"`
+	Name        string            `json:"name"`
+	Doc         string            `json:"doc"`
+	ServiceDoc  string            `json:"serviceDoc"`
+	IsOneWay    bool              `json:"isOneWay"`
+	Annotations map[string]string `json:"annotations"`
+	Arguments   []*FieldSchema    `json:"arguments"`
+	ResultType  string            `json:"resultType"`
+	Exceptions  []*FieldSchema    `json:"exceptions"`
+}
+
+type FieldSchema struct {
+	ID          int16             `json:"id"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Required    bool              `json:"required"`
+	Default     interface{}       `json:"default"`
+	Doc         string            `json:"doc"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type FileSchema struct {
+	Path      string            `json:"path"`
+	Structs   []*StructSchema   `json:"structs"`
+	Enums     []*EnumSchema     `json:"enums"`
+	Typedefs  []*TypedefSchema  `json:"typedefs"`
+	Constants []*ConstantSchema `json:"constants"`
+}
+
+type StructSchema struct {
+	Name        string            `json:"name"`
+	Kind        string            `json:"kind"`
+	Doc         string            `json:"doc"`
+	Annotations map[string]string `json:"annotations"`
+	Fields      []*FieldSchema    `json:"fields"`
+}
+
+type EnumSchema struct {
+	Name        string            `json:"name"`
+	Doc         string            `json:"doc"`
+	Annotations map[string]string `json:"annotations"`
+	Items       []*EnumItemSchema `json:"items"`
+}
+
+type EnumItemSchema struct {
+	Name        string            `json:"name"`
+	Value       int32             `json:"value"`
+	Doc         string            `json:"doc"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type TypedefSchema struct {
+	Name        string            `json:"name"`
+	Target      string            `json:"target"`
+	Doc         string            `json:"doc"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type ConstantSchema struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+	Doc   string      `json:"doc"`
+}
+
+func (t *ServiceTree) FunctionSchema(id string) (*FunctionSchema, error) {
+	function := t.Function(id)
+	if function == nil {
+		return nil, fmt.Errorf("%w: %s", errThriftUnknownFunction, id)
+	}
+
+	spec := function.Spec()
+
+	schema := &FunctionSchema{
+		ServiceName: function.ServiceName(),
+		Name:        spec.Name,
+		IsOneWay:    spec.OneWay,
+		Annotations: spec.Annotations,
+		Arguments:   fieldSchemas(compile.FieldGroup(spec.ArgsSpec)),
+	}
+
+	if spec.ResultSpec != nil {
+		if spec.ResultSpec.ReturnType != nil {
+			schema.ResultType = spec.ResultSpec.ReturnType.ThriftName()
+		}
+
+		schema.Exceptions = fieldSchemas(spec.ResultSpec.Exceptions)
+	}
+
+	schema.ServiceDoc = t.serviceDoc(function.service)
+	schema.Doc = t.functionDoc(function.service, spec.Name)
+
+	return schema, nil
+}
+
+func (t *ServiceTree) FileSchemas() []*FileSchema {
+	fileSchemas := make([]*FileSchema, 0, len(t.modules))
+
+	for _, module := range t.modules {
+		fileSchema := &FileSchema{
+			Path: module.ThriftPath,
+		}
+
+		typeNames := lo.Keys(module.Types)
+		sort.Strings(typeNames)
+
+		for _, typeName := range typeNames {
+			switch spec := module.Types[typeName].(type) {
+			case *compile.StructSpec:
+				fileSchema.Structs = append(fileSchema.Structs, &StructSchema{
+					Name:        spec.Name,
+					Kind:        structKind(spec.Type),
+					Doc:         spec.Doc,
+					Annotations: spec.Annotations,
+					Fields:      fieldSchemas(spec.Fields),
+				})
+			case *compile.EnumSpec:
+				fileSchema.Enums = append(fileSchema.Enums, enumSchema(spec))
+			case *compile.TypedefSpec:
+				fileSchema.Typedefs = append(fileSchema.Typedefs, &TypedefSchema{
+					Name:        spec.Name,
+					Target:      spec.Target.ThriftName(),
+					Doc:         spec.Doc,
+					Annotations: spec.Annotations,
+				})
+			}
+		}
+
+		constantNames := lo.Keys(module.Constants)
+		sort.Strings(constantNames)
+
+		for _, constantName := range constantNames {
+			constant := module.Constants[constantName]
+
+			fileSchema.Constants = append(fileSchema.Constants, &ConstantSchema{
+				Name:  constant.Name,
+				Type:  constant.Type.ThriftName(),
+				Value: constantToSkeleton(constant.Value),
+				Doc:   constant.Doc,
+			})
+		}
+
+		fileSchemas = append(fileSchemas, fileSchema)
+	}
+
+	return fileSchemas
+}
+
+// serviceDoc and functionDoc read docstrings from the parsed IDL, compiled services and functions don't keep them.
+func (t *ServiceTree) serviceDoc(service *compile.ServiceSpec) string {
+	astService := t.astService(service)
+	if astService == nil {
+		return ""
+	}
+
+	return astService.Doc
+}
+
+func (t *ServiceTree) functionDoc(service *compile.ServiceSpec, functionName string) string {
+	for spec := service; spec != nil; spec = spec.Parent {
+		if _, ok := spec.Functions[functionName]; !ok {
+			continue
+		}
+
+		astService := t.astService(spec)
+		if astService == nil {
+			return ""
+		}
+
+		for _, function := range astService.Functions {
+			if function.Name == functionName {
+				return function.Doc
+			}
+		}
+
+		return ""
+	}
+
+	return ""
+}
+
+func (t *ServiceTree) astService(service *compile.ServiceSpec) *ast.Service {
+	module, ok := lo.Find(t.modules, func(module *compile.Module) bool {
+		return module.ThriftPath == service.File
+	})
+	if !ok {
+		return nil
+	}
+
+	program, err := idl.Parse(module.Raw)
+	if err != nil {
+		return nil
+	}
+
+	for _, definition := range program.Definitions {
+		if astService, ok := definition.(*ast.Service); ok && astService.Name == service.Name {
+			return astService
+		}
+	}
+
+	return nil
+}
+
+func fieldSchemas(fieldGroup compile.FieldGroup) []*FieldSchema {
+	return lo.Map(fieldGroup, func(field *compile.FieldSpec, _ int) *FieldSchema {
+		return &FieldSchema{
+			ID:          field.ID,
+			Name:        field.Name,
+			Type:        field.Type.ThriftName(),
+			Required:    field.Required,
+			Default:     constantToSkeleton(field.Default),
+			Doc:         field.Doc,
+			Annotations: field.Annotations,
+		}
+	})
+}
+
+func enumSchema(spec *compile.EnumSpec) *EnumSchema {
+	return &EnumSchema{
+		Name:        spec.Name,
+		Doc:         spec.Doc,
+		Annotations: spec.Annotations,
+		Items: lo.Map(spec.Items, func(item compile.EnumItem, _ int) *EnumItemSchema {
+			return &EnumItemSchema{
+				Name:        item.Name,
+				Value:       item.Value,
+				Doc:         item.Doc,
+				Annotations: item.Annotations,
+			}
+		}),
+	}
+}
+
+func structKind(structType ast.StructureType) string {
+	switch structType {
+	case ast.UnionType:
+		return "union"
+	case ast.ExceptionType:
+		return "exception"
+	default:
+		return "struct"
+	}
+}
@@ -2,45 +2,29 @@ package thrift
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/samber/lo"
 	"go.uber.org/thriftrw/compile"
 )
 
 type ServiceTree struct {
-	services       []*ServiceTreeService
+	files          []*ServiceTreeFile
+	modules        []*compile.Module
 	functionsByIDs map[string]*ServiceTreeFunction
 }
 
-func NewServiceTree(module *compile.Module) (*ServiceTree, error) {
+func NewServiceTree(modules []*compile.Module) (*ServiceTree, error) {
 	serviceTree := &ServiceTree{
 		functionsByIDs: make(map[string]*ServiceTreeFunction),
 	}
 
-	if module == nil {
-		return serviceTree, nil
-	}
-
-	serviceTree.services = make([]*ServiceTreeService, 0, len(module.Services))
-
-	for _, service := range module.Services {
-		serviceTreeService := &ServiceTreeService{
-			id:   service.Name,
-			name: service.Name,
-		}
-
-		for _, function := range service.Functions {
-			serviceTreeFunction := &ServiceTreeFunction{
-				id:           fmt.Sprintf("%s_%s", service.Name, function.Name),
-				functionName: function.Name,
-				serviceName:  service.Name,
-				spec:         function,
-			}
-
-			serviceTreeService.functions = append(serviceTreeService.functions, serviceTreeFunction)
-			serviceTree.functionsByIDs[serviceTreeFunction.id] = serviceTreeFunction
-		}
+	visitedPaths := make(map[string]bool)
 
-		serviceTree.services = append(serviceTree.services, serviceTreeService)
+	for _, module := range modules {
+		serviceTree.addModule(module, visitedPaths)
 	}
 
 	return serviceTree, nil
@@ -50,41 +34,166 @@ type ServiceTreeNode struct {
 	ID         string             `json:"id"`
 	Label      string             `json:"label"`
 	Selectable bool               `json:"selectable"`
+	IsOneWay   bool               `json:"isOneWay"`
 	Children   []*ServiceTreeNode `json:"children"`
 }
 
 func (t *ServiceTree) Nodes() []*ServiceTreeNode {
-	nodes := make([]*ServiceTreeNode, 0, len(t.services))
+	nodes := make([]*ServiceTreeNode, 0, len(t.files))
 
-	for _, service := range t.services {
-		serviceNode := &ServiceTreeNode{
-			ID:         service.id,
-			Label:      service.name,
+	for _, file := range t.files {
+		fileNode := &ServiceTreeNode{
+			ID:         file.path,
+			Label:      filepath.Base(file.path),
 			Selectable: false,
+			Children:   make([]*ServiceTreeNode, 0, len(file.services)),
 		}
 
-		nodes = append(nodes, serviceNode)
-
-		serviceNode.Children = make([]*ServiceTreeNode, 0, len(service.functions))
+		nodes = append(nodes, fileNode)
 
-		for _, function := range service.functions {
-			functionNode := &ServiceTreeNode{
-				ID:         function.id,
-				Label:      function.functionName,
-				Selectable: true,
+		for _, service := range file.services {
+			serviceNode := &ServiceTreeNode{
+				ID:         service.id,
+				Label:      service.name,
+				Selectable: false,
+				Children:   make([]*ServiceTreeNode, 0, len(service.functions)),
 			}
 
-			serviceNode.Children = append(serviceNode.Children, functionNode)
+			fileNode.Children = append(fileNode.Children, serviceNode)
+
+			for _, function := range service.functions {
+				functionNode := &ServiceTreeNode{
+					ID:         function.id,
+					Label:      function.functionName,
+					Selectable: true,
+					IsOneWay:   function.spec.OneWay,
+				}
+
+				serviceNode.Children = append(serviceNode.Children, functionNode)
+			}
 		}
 	}
 
 	return nodes
 }
 
+// Function returns nil for an unknown id, as well as before any file is loaded.
 func (t *ServiceTree) Function(id string) *ServiceTreeFunction {
+	if t == nil {
+		return nil
+	}
+
 	return t.functionsByIDs[id]
 }
 
+// LegacyFunctionID maps a "Service_function" id, which projects had before they could hold several files,
+// to the id of that function in the given file, as the only file of such a project is the first one now.
+func (t *ServiceTree) LegacyFunctionID(legacyID, filePath string) (string, bool) {
+	if legacyID == "" || t.functionsByIDs[legacyID] != nil {
+		return "", false
+	}
+
+	var matchingFunctionIDs []string
+
+	for _, file := range t.files {
+		for _, service := range file.services {
+			for _, function := range service.functions {
+				if fmt.Sprintf("%s_%s", service.name, function.functionName) != legacyID {
+					continue
+				}
+
+				if filepath.Clean(file.path) == filepath.Clean(filePath) {
+					return function.id, true
+				}
+
+				matchingFunctionIDs = append(matchingFunctionIDs, function.id)
+			}
+		}
+	}
+
+	if len(matchingFunctionIDs) != 1 {
+		return "", false
+	}
+
+	return matchingFunctionIDs[0], true
+}
+
+func (t *ServiceTree) addModule(module *compile.Module, visitedPaths map[string]bool) {
+	if module == nil || visitedPaths[module.ThriftPath] {
+		return
+	}
+
+	visitedPaths[module.ThriftPath] = true
+	t.modules = append(t.modules, module)
+
+	file := &ServiceTreeFile{
+		path: module.ThriftPath,
+	}
+
+	serviceNames := lo.Keys(module.Services)
+	sort.Strings(serviceNames)
+
+	for _, serviceName := range serviceNames {
+		service := module.Services[serviceName]
+
+		serviceTreeService := &ServiceTreeService{
+			id:   fmt.Sprintf("%s:%s", module.ThriftPath, service.Name),
+			name: service.Name,
+		}
+
+		for _, function := range serviceFunctions(service) {
+			serviceTreeFunction := &ServiceTreeFunction{
+				id:           fmt.Sprintf("%s_%s", serviceTreeService.id, function.Name),
+				functionName: function.Name,
+				serviceName:  service.Name,
+				service:      service,
+				spec:         function,
+			}
+
+			serviceTreeService.functions = append(serviceTreeService.functions, serviceTreeFunction)
+			t.functionsByIDs[serviceTreeFunction.id] = serviceTreeFunction
+		}
+
+		file.services = append(file.services, serviceTreeService)
+	}
+
+	if len(file.services) > 0 {
+		t.files = append(t.files, file)
+	}
+
+	includeNames := lo.Keys(module.Includes)
+	sort.Strings(includeNames)
+
+	for _, includeName := range includeNames {
+		t.addModule(module.Includes[includeName].Module, visitedPaths)
+	}
+}
+
+// serviceFunctions returns the functions of a service including the ones inherited from its parents.
+func serviceFunctions(service *compile.ServiceSpec) []*compile.FunctionSpec {
+	functionsByNames := make(map[string]*compile.FunctionSpec)
+
+	for spec := service; spec != nil; spec = spec.Parent {
+		for name, function := range spec.Functions {
+			if _, ok := functionsByNames[name]; !ok {
+				functionsByNames[name] = function
+			}
+		}
+	}
+
+	functionNames := lo.Keys(functionsByNames)
+	sort.Strings(functionNames)
+
+	return lo.Map(functionNames, func(name string, _ int) *compile.FunctionSpec {
+		return functionsByNames[name]
+	})
+}
+
+type ServiceTreeFile struct {
+	path     string
+	services []*ServiceTreeService
+}
+
 type ServiceTreeService struct {
 	id        string
 	name      string
@@ -95,6 +204,7 @@ type ServiceTreeFunction struct {
 	id           string
 	functionName string
 	serviceName  string
+	service      *compile.ServiceSpec
 	spec         *compile.FunctionSpec
 }
 
@@ -102,6 +212,48 @@ func (f *ServiceTreeFunction) Spec() *compile.FunctionSpec {
 	return f.spec
 }
 
+func (f *ServiceTreeFunction) IsOneWay() bool {
+	return f.spec.OneWay
+}
+
 func (f *ServiceTreeFunction) ServiceName() string {
 	return f.serviceName
 }
+
+// FunctionByName finds a function by an envelope name, which is either "method" or "Service:method"
+// for multiplexed calls. A name matching functions of several services is reported as ambiguous.
+func (t *ServiceTree) FunctionByName(name string) (*ServiceTreeFunction, error) {
+	serviceName, functionName, isMultiplexed := strings.Cut(name, ":")
+	if !isMultiplexed {
+		serviceName, functionName = "", name
+	}
+
+	if t == nil {
+		return nil, fmt.Errorf("%w: %s", errThriftUnknownFunction, name)
+	}
+
+	var matches []*ServiceTreeFunction
+
+	for _, file := range t.files {
+		for _, service := range file.services {
+			if serviceName != "" && service.name != serviceName {
+				continue
+			}
+
+			for _, function := range service.functions {
+				if function.functionName == functionName {
+					matches = append(matches, function)
+				}
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", errThriftUnknownFunction, name)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errThriftAmbiguousFunction, name)
+	}
+}
@@ -0,0 +1,222 @@
+package thrift
+
+import (
+	"encoding/json"
+	"fmt"
+
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+)
+
+type SkeletonField struct {
+	Path        string            `json:"path"`
+	Type        string            `json:"type"`
+	Required    bool              `json:"required"`
+	IsUnion     bool              `json:"isUnion"`
+	Default     interface{}       `json:"default"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type skeletonBuilder struct {
+	fields      []*SkeletonField
+	structStack map[*compile.StructSpec]bool
+}
+
+// buildSkeleton returns a request payload yab accepts as-is together with a description of every field in it.
+func buildSkeleton(
+	fieldGroup compile.FieldGroup,
+) (*orderedmap.OrderedMap[string, interface{}], []*SkeletonField, error) {
+	builder := &skeletonBuilder{
+		structStack: make(map[*compile.StructSpec]bool),
+	}
+
+	payload, err := builder.fieldGroup("", fieldGroup, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return payload, builder.fields, nil
+}
+
+func (b *skeletonBuilder) fieldGroup(
+	path string,
+	fieldGroup compile.FieldGroup,
+	isUnion bool,
+) (*orderedmap.OrderedMap[string, interface{}], error) {
+	result := orderedmap.New[string, interface{}]()
+
+	for _, field := range fieldGroup {
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = fmt.Sprintf("%s.%s", path, field.Name)
+		}
+
+		b.fields = append(b.fields, &SkeletonField{
+			Path:        fieldPath,
+			Type:        field.Type.ThriftName(),
+			Required:    field.Required,
+			IsUnion:     isUnion,
+			Default:     constantToSkeleton(field.Default),
+			Annotations: field.Annotations,
+		})
+
+		// only the first member of a union is set, the rest are listed in the fields
+		if isUnion && result.Len() > 0 {
+			continue
+		}
+
+		var (
+			value interface{}
+			err   error
+		)
+
+		if field.Default != nil {
+			value = constantToSkeleton(field.Default)
+		} else {
+			value, err = b.typeSpec(fieldPath, field.Type)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if value == nil {
+			continue
+		}
+
+		result.Set(field.Name, value)
+	}
+
+	return result, nil
+}
+
+// nolint: cyclop
+func (b *skeletonBuilder) typeSpec(path string, typ compile.TypeSpec) (interface{}, error) {
+	switch spec := typ.(type) {
+	case *compile.StructSpec:
+		if b.structStack[spec] {
+			return nil, nil
+		}
+
+		b.structStack[spec] = true
+		defer delete(b.structStack, spec)
+
+		return b.fieldGroup(path, spec.Fields, spec.Type == ast.UnionType)
+	case *compile.TypedefSpec:
+		return b.typeSpec(path, spec.Target)
+	case *compile.EnumSpec:
+		if len(spec.Items) == 0 {
+			return 0, nil
+		}
+
+		return spec.Items[0].Name, nil
+	case *compile.StringSpec, *compile.BinarySpec:
+		return "", nil
+	case *compile.BoolSpec:
+		return false, nil
+	case *compile.I8Spec, *compile.I16Spec, *compile.I32Spec, *compile.I64Spec:
+		return 0, nil
+	case *compile.DoubleSpec:
+		return 0.0, nil
+	case *compile.ListSpec:
+		return b.listSpec(path, spec.ValueSpec)
+	case *compile.SetSpec:
+		return b.listSpec(path, spec.ValueSpec)
+	case *compile.MapSpec:
+		value, err := b.typeSpec(path+"{}", spec.ValueSpec)
+		if err != nil {
+			return nil, err
+		}
+
+		if value == nil {
+			return map[string]interface{}{}, nil
+		}
+
+		key, err := b.typeSpec(path+"{key}", spec.KeySpec)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{skeletonMapKey(key): value}, nil
+	default:
+		return nil, fmt.Errorf("failed to parse %v: %w", typ, errThriftUnknownType)
+	}
+}
+
+func (b *skeletonBuilder) listSpec(path string, valueSpec compile.TypeSpec) (interface{}, error) {
+	value, err := b.typeSpec(path+"[]", valueSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return []interface{}{}, nil
+	}
+
+	return []interface{}{value}, nil
+}
+
+// skeletonMapKey renders a map key as a string, yab parses non-string keys back from it.
+func skeletonMapKey(key interface{}) string {
+	if keyString, ok := key.(string); ok {
+		return keyString
+	}
+
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Sprint(key)
+	}
+
+	return string(keyJSON)
+}
+
+func constantToSkeleton(value compile.ConstantValue) interface{} {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case compile.ConstantBool:
+		return bool(value)
+	case compile.ConstantDouble:
+		return float64(value)
+	case compile.ConstantInt:
+		return int64(value)
+	case compile.ConstantString:
+		return string(value)
+	case compile.ConstReference:
+		return constantToSkeleton(value.Target.Value)
+	case compile.EnumItemReference:
+		return value.Item.Name
+	case compile.ConstantSet:
+		return constantListToSkeleton(value)
+	case compile.ConstantList:
+		return constantListToSkeleton(value)
+	case compile.ConstantMap:
+		result := make(map[string]interface{}, len(value))
+
+		for _, item := range value {
+			result[skeletonMapKey(constantToSkeleton(item.Key))] = constantToSkeleton(item.Value)
+		}
+
+		return result
+	case *compile.ConstantStruct:
+		result := make(map[string]interface{}, len(value.Fields))
+
+		for name, fieldValue := range value.Fields {
+			result[name] = constantToSkeleton(fieldValue)
+		}
+
+		return result
+	default:
+		return nil
+	}
+}
+
+func constantListToSkeleton(values []compile.ConstantValue) []interface{} {
+	result := make([]interface{}, 0, len(values))
+
+	for _, value := range values {
+		result = append(result, constantToSkeleton(value))
+	}
+
+	return result
+}
@@ -0,0 +1,218 @@
+package thrift
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/uber/tchannel-go"
+	tchannelthrift "github.com/uber/tchannel-go/thrift"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+)
+
+const defaultYARPCCaller = "multibase"
+
+// YARPC holds the routing settings used by the TChannel transport and sent as Rpc-* headers over HTTP.
+type YARPC struct {
+	Caller          string `json:"caller"`
+	Service         string `json:"service"`
+	RoutingKey      string `json:"routingKey"`
+	RoutingDelegate string `json:"routingDelegate"`
+	ShardKey        string `json:"shardKey"`
+}
+
+func newYARPC() *YARPC {
+	return &YARPC{
+		Caller: defaultYARPCCaller,
+	}
+}
+
+func (y *YARPC) caller() string {
+	if y == nil || y.Caller == "" {
+		return defaultYARPCCaller
+	}
+
+	return y.Caller
+}
+
+// applyHTTPHeaders sets the headers a YARPC HTTP inbound expects, it does nothing until a service is set.
+func (y *YARPC) applyHTTPHeaders(header http.Header, procedure string, timeout time.Duration) {
+	if y == nil || y.Service == "" {
+		return
+	}
+
+	header.Set("Rpc-Service", y.Service)
+	header.Set("Rpc-Procedure", procedure)
+	header.Set("Rpc-Caller", y.caller())
+	header.Set("Rpc-Encoding", "thrift")
+	header.Set("Context-TTL-MS", strconv.FormatInt(timeout.Milliseconds(), 10))
+
+	if y.RoutingKey != "" {
+		header.Set("Rpc-Routing-Key", y.RoutingKey)
+	}
+
+	if y.RoutingDelegate != "" {
+		header.Set("Rpc-Routing-Delegate", y.RoutingDelegate)
+	}
+
+	if y.ShardKey != "" {
+		header.Set("Rpc-Shard-Key", y.ShardKey)
+	}
+}
+
+// executeTChannelRequest sends a binary enveloped request over TChannel
+// and returns the reply wrapped back into a binary envelope.
+// yab's TChannel transport is not used as its channel cannot be closed, every form setting change would leak one,
+// and it pulls yarpc with its metrics dependencies into the app.
+// nolint: funlen
+func (f *Form) executeTChannelRequest(
+	ctx context.Context,
+	formRequest *formRequest,
+	procedure string,
+	request []byte,
+) ([]byte, map[string]string, error) {
+	yarpc := formRequest.yarpc
+	if yarpc.Service == "" {
+		return nil, nil, errMissingYARPCService
+	}
+
+	envelope, err := protocol.Binary.DecodeEnveloped(bytes.NewReader(request))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode a binary request: %w", err)
+	}
+
+	arg2 := &bytes.Buffer{}
+
+	err = tchannelthrift.WriteHeaders(arg2, headersToMap(formRequest.call.headers))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to write tchannel headers: %w", err)
+	}
+
+	arg3 := &bytes.Buffer{}
+
+	err = protocol.Binary.Encode(envelope.Value, arg3)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode a tchannel request: %w", err)
+	}
+
+	channel, err := f.fetchTChannel(yarpc.caller())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	call, err := channel.BeginCall(ctx, formRequest.call.address, yarpc.Service, procedure, &tchannel.CallOptions{
+		Format:          tchannel.Thrift,
+		RoutingKey:      yarpc.RoutingKey,
+		RoutingDelegate: yarpc.RoutingDelegate,
+		ShardKey:        yarpc.ShardKey,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin a tchannel call: %w", err)
+	}
+
+	err = tchannel.NewArgWriter(call.Arg2Writer()).Write(arg2.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to write tchannel arg2: %w", err)
+	}
+
+	err = tchannel.NewArgWriter(call.Arg3Writer()).Write(arg3.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to write tchannel arg3: %w", err)
+	}
+
+	var responseArg2, responseArg3 []byte
+
+	err = tchannel.NewArgReader(call.Response().Arg2Reader()).Read(&responseArg2)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tchannel arg2: %w", err)
+	}
+
+	err = tchannel.NewArgReader(call.Response().Arg3Reader()).Read(&responseArg3)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tchannel arg3: %w", err)
+	}
+
+	responseHeaders, err := tchannelthrift.ReadHeaders(bytes.NewReader(responseArg2))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tchannel headers: %w", err)
+	}
+
+	responseValue, err := protocol.Binary.Decode(bytes.NewReader(responseArg3), wire.TStruct)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode a tchannel response: %w", err)
+	}
+
+	response := &bytes.Buffer{}
+
+	err = protocol.Binary.EncodeEnveloped(wire.Envelope{
+		Name:  envelope.Name,
+		Type:  wire.Reply,
+		SeqID: envelope.SeqID,
+		Value: responseValue,
+	}, response)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode a binary response: %w", err)
+	}
+
+	return response.Bytes(), responseHeaders, nil
+}
+
+func (f *Form) fetchTChannel(caller string) (*tchannel.Channel, error) {
+	f.clientMutex.Lock()
+	defer f.clientMutex.Unlock()
+
+	if f.isClosed {
+		return nil, errFormClosed
+	}
+
+	if f.tchannel != nil && f.tchannel.ServiceName() == caller {
+		return f.tchannel, nil
+	}
+
+	f.resetTChannel()
+
+	channel, err := tchannel.NewChannel(caller, &tchannel.ChannelOptions{
+		Logger: tchannel.NullLogger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a tchannel: %w", err)
+	}
+
+	f.tchannel = channel
+
+	return channel, nil
+}
+
+func (f *Form) closeTChannel() {
+	f.clientMutex.Lock()
+	defer f.clientMutex.Unlock()
+
+	f.resetTChannel()
+}
+
+func (f *Form) resetTChannel() {
+	if f.tchannel == nil {
+		return
+	}
+
+	f.tchannel.Close()
+	f.tchannel = nil
+}
+
+func headersToMap(headers []*Header) map[string]string {
+	result := make(map[string]string, len(headers))
+
+	for _, header := range headers {
+		if header.Key == "" {
+			continue
+		}
+
+		result[header.Key] = header.Value
+	}
+
+	return result
+}
@@ -0,0 +1,226 @@
+package thrift
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/uber/tchannel-go"
+	tchannelthrift "github.com/uber/tchannel-go/thrift"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+)
+
+type testTChannelCall struct {
+	procedure string
+	headers   map[string]string
+	args      wire.Value
+}
+
+func TestFormSendRequestTChannel(t *testing.T) {
+	serviceTree := newTestServiceTree(t)
+
+	calls := make(chan *testTChannelCall, 1)
+	address := startTestTChannelServer(t, "greeter", calls)
+
+	form := &Form{
+		Transport: TransportTChannel,
+		Protocol:  ProtocolCompact,
+		YARPC:     &YARPC{Caller: "tester", Service: "greeter"},
+		Headers:   []*Header{{Key: "x-request-id", Value: "1"}},
+		IsDebug:   true,
+	}
+
+	function := testFunction(t, serviceTree, "greet")
+	call := &resolvedCall{
+		functionID: function.id,
+		address:    address,
+		headers:    form.Headers,
+		payload:    `{"name": "multibase", "times": 2}`,
+	}
+
+	responseJSON, err := form.SendRequest(form.newRequest(call, function))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := &ResponseJSON{}
+
+	err = json.Unmarshal([]byte(responseJSON), response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Status != ResponseStatusSuccess {
+		t.Fatalf("unexpected response status %q: %+v", response.Status, response.TransportException)
+	}
+
+	assertJSONEqual(t, map[string]interface{}{"text": "hello multibase", "count": 2}, response.Result)
+	assertJSONEqual(t, map[string]string{"x-served-by": "greeter"}, response.Headers)
+
+	if !response.Debug.Request.IsReconstructed || !response.Debug.Response.IsReconstructed {
+		t.Fatalf("expected tchannel dumps to be marked as reconstructed, got %+v", response.Debug)
+	}
+
+	tchannelCall := <-calls
+	if tchannelCall.procedure != "Greeter::greet" {
+		t.Fatalf("unexpected procedure %s", tchannelCall.procedure)
+	}
+
+	if tchannelCall.headers["x-request-id"] != "1" {
+		t.Fatalf("unexpected headers %v", tchannelCall.headers)
+	}
+
+	fields := tchannelCall.args.GetStruct().Fields
+	if len(fields) != 2 || fields[0].Value.GetString() != "multibase" || fields[1].Value.GetI64() != 2 {
+		t.Fatalf("unexpected args %v", tchannelCall.args)
+	}
+}
+
+func TestFormSendRequestYARPCHeaders(t *testing.T) {
+	serviceTree := newTestServiceTree(t)
+
+	mockServer := newMockServer()
+	mockServer.SetResponse(greetResponse(t, serviceTree))
+	mockServer.setServiceTree(serviceTree)
+
+	requestHeaders := make(chan http.Header, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestHeaders <- request.Header.Clone()
+
+		mockServer.handleHTTP(writer, request, ProtocolBinary)
+	}))
+	t.Cleanup(server.Close)
+
+	form := &Form{
+		Transport: TransportHTTP,
+		Protocol:  ProtocolBinary,
+		YARPC:     &YARPC{Service: "greeter", RoutingKey: "canary", ShardKey: "7"},
+	}
+
+	response := sendTestRequest(
+		t,
+		form,
+		serviceTree,
+		"greet",
+		strings.TrimPrefix(server.URL, "http://"),
+		`{"name": "multibase"}`,
+	)
+	if response.Status != ResponseStatusSuccess {
+		t.Fatalf("unexpected response status %q", response.Status)
+	}
+
+	header := <-requestHeaders
+
+	expectedHeaders := map[string]string{
+		"Rpc-Service":     "greeter",
+		"Rpc-Procedure":   "Greeter::greet",
+		"Rpc-Caller":      defaultYARPCCaller,
+		"Rpc-Encoding":    "thrift",
+		"Rpc-Routing-Key": "canary",
+		"Rpc-Shard-Key":   "7",
+	}
+
+	for key, value := range expectedHeaders {
+		if header.Get(key) != value {
+			t.Fatalf("expected %s header to be %q, got %q", key, value, header.Get(key))
+		}
+	}
+
+	if header.Get("Context-TTL-MS") == "" {
+		t.Fatal("expected Context-TTL-MS header to be set")
+	}
+}
+
+// startTestTChannelServer serves Greeter::greet over tchannel, replying with a greeting and a header.
+func startTestTChannelServer(t *testing.T, serviceName string, calls chan<- *testTChannelCall) string {
+	t.Helper()
+
+	channel, err := tchannel.NewChannel(serviceName, &tchannel.ChannelOptions{Logger: tchannel.NullLogger})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel.Register(tchannel.HandlerFunc(func(ctx context.Context, inboundCall *tchannel.InboundCall) {
+		var arg2, arg3 []byte
+
+		err := tchannel.NewArgReader(inboundCall.Arg2Reader()).Read(&arg2)
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		err = tchannel.NewArgReader(inboundCall.Arg3Reader()).Read(&arg3)
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		headers, err := tchannelthrift.ReadHeaders(bytes.NewReader(arg2))
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		args, err := protocol.Binary.Decode(bytes.NewReader(arg3), wire.TStruct)
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		calls <- &testTChannelCall{procedure: inboundCall.MethodString(), headers: headers, args: args}
+
+		responseHeaders := &bytes.Buffer{}
+
+		err = tchannelthrift.WriteHeaders(responseHeaders, map[string]string{"x-served-by": serviceName})
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		result := &bytes.Buffer{}
+
+		err = protocol.Binary.Encode(wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+			{ID: 0, Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueString("hello multibase")},
+				{ID: 2, Value: wire.NewValueI32(2)},
+			}})},
+		}}), result)
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		err = tchannel.NewArgWriter(inboundCall.Response().Arg2Writer()).Write(responseHeaders.Bytes())
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		err = tchannel.NewArgWriter(inboundCall.Response().Arg3Writer()).Write(result.Bytes())
+		if err != nil {
+			t.Error(err)
+		}
+	}), "Greeter::greet")
+
+	err = channel.ListenAndServe("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(channel.Close)
+
+	return channel.PeerInfo().HostPort
+}
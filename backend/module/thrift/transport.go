@@ -0,0 +1,151 @@
+package thrift
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+type Transport string
+
+const (
+	TransportHTTP     Transport = "http"
+	TransportFramed   Transport = "framed"
+	TransportBuffered Transport = "buffered"
+	TransportTChannel Transport = "tchannel"
+)
+
+const maxFrameSize = 64 << 20
+
+var (
+	errUnknownTransport    = errors.New("unknown thrift transport")
+	errFrameTooLarge       = errors.New("thrift frame is too large")
+	errMissingYARPCService = errors.New("tchannel transport requires a service name")
+)
+
+func validateTransport(transport Transport) error {
+	switch transport {
+	case TransportHTTP, TransportFramed, TransportBuffered, TransportTChannel:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errUnknownTransport, transport)
+	}
+}
+
+// nolint: cyclop
+func executeSocketRequest(
+	ctx context.Context,
+	transport Transport,
+	protocolName Protocol,
+	address string,
+	payload []byte,
+	isOneWay bool,
+) (_ []byte, rerr error) {
+	dialer := &net.Dialer{}
+
+	connection, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", address, err)
+	}
+
+	defer func() {
+		err := connection.Close()
+		if err != nil && !errors.Is(err, net.ErrClosed) {
+			rerr = errors.Join(rerr, fmt.Errorf("failed to close a connection: %w", err))
+		}
+	}()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := connection.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("failed to set a connection deadline: %w", err)
+		}
+	}
+
+	requestDone := make(chan struct{})
+	defer close(requestDone)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = connection.Close()
+		case <-requestDone:
+		}
+	}()
+
+	var responseBody []byte
+
+	switch transport {
+	case TransportFramed:
+		responseBody, err = exchangeFramed(connection, payload, isOneWay)
+	case TransportBuffered:
+		responseBody, err = exchangeBuffered(connection, protocolName, payload, isOneWay)
+	default:
+		err = fmt.Errorf("%w: %s", errUnknownTransport, transport)
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("thrift request canceled: %w", ctx.Err())
+		}
+
+		return nil, err
+	}
+
+	return responseBody, nil
+}
+
+func exchangeFramed(connection net.Conn, payload []byte, isOneWay bool) ([]byte, error) {
+	frameHeader := make([]byte, 4) // nolint: gomnd
+	binary.BigEndian.PutUint32(frameHeader, uint32(len(payload)))
+
+	_, err := connection.Write(append(frameHeader, payload...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write a thrift frame: %w", err)
+	}
+
+	if isOneWay {
+		return nil, nil
+	}
+
+	return readFrame(connection)
+}
+
+func readFrame(reader io.Reader) ([]byte, error) {
+	frameHeader := make([]byte, 4) // nolint: gomnd
+
+	_, err := io.ReadFull(reader, frameHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read a thrift frame header: %w", err)
+	}
+
+	frameSize := binary.BigEndian.Uint32(frameHeader)
+	if frameSize > maxFrameSize {
+		return nil, fmt.Errorf("%w: %d bytes", errFrameTooLarge, frameSize)
+	}
+
+	frame := make([]byte, frameSize)
+
+	_, err = io.ReadFull(reader, frame)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read a thrift frame: %w", err)
+	}
+
+	return frame, nil
+}
+
+func exchangeBuffered(connection net.Conn, protocolName Protocol, payload []byte, isOneWay bool) ([]byte, error) {
+	_, err := connection.Write(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write a thrift message: %w", err)
+	}
+
+	if isOneWay {
+		return nil, nil
+	}
+
+	return readMessage(protocolName, bufio.NewReader(connection))
+}
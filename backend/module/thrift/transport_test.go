@@ -0,0 +1,316 @@
+package thrift
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testIDL = `
+exception NotFound {
+  1: string message
+}
+
+struct Greeting {
+  1: string text
+  2: i32 count
+  3: list<string> tags
+}
+
+service Greeter {
+  Greeting greet(1: string name, 2: i64 times) throws (1: NotFound notFound)
+  oneway void notify(1: string name)
+}
+`
+
+func TestFormSendRequestRoundTrip(t *testing.T) {
+	serviceTree := newTestServiceTree(t)
+
+	var forms []*Form
+
+	for _, transport := range []Transport{TransportHTTP, TransportFramed, TransportBuffered} {
+		for _, protocol := range []Protocol{ProtocolBinary, ProtocolCompact, ProtocolJSON} {
+			for _, isMultiplexed := range []bool{false, true} {
+				form := &Form{Transport: transport, Protocol: protocol, IsMultiplexed: isMultiplexed}
+				forms = append(forms, form)
+			}
+		}
+	}
+
+	for _, form := range forms {
+		form := form
+		name := fmt.Sprintf("%s/%s/multiplexed=%t", form.Transport, form.Protocol, form.IsMultiplexed)
+
+		t.Run(name, func(t *testing.T) {
+			testFormSendRequestRoundTrip(t, serviceTree, form)
+		})
+	}
+}
+
+func testFormSendRequestRoundTrip(t *testing.T, serviceTree *ServiceTree, form *Form) {
+	t.Helper()
+
+	calls := make(chan *MockCall, 1)
+	address := startTestMockServer(
+		t,
+		serviceTree,
+		form.Transport,
+		form.Protocol,
+		greetResponse(t, serviceTree),
+		calls,
+	)
+
+	response := sendTestRequest(t, form, serviceTree, "greet", address, `{"name": "multibase", "times": 2}`)
+	if response.Status != ResponseStatusSuccess {
+		t.Fatalf("unexpected response status %q: %+v", response.Status, response.TransportException)
+	}
+
+	expectedResult := map[string]interface{}{
+		"text":  "hello multibase",
+		"count": float64(2),
+		"tags":  []interface{}{"a", "b"},
+	}
+	assertJSONEqual(t, expectedResult, response.Result)
+
+	call := <-calls
+	if call.Error != "" {
+		t.Fatalf("mock server failed to handle the call: %s", call.Error)
+	}
+
+	assertJSONEqual(t, map[string]interface{}{"name": "multibase", "times": float64(2)}, call.Request)
+}
+
+func TestFormSendRequestException(t *testing.T) {
+	serviceTree := newTestServiceTree(t)
+
+	for _, protocolName := range []Protocol{ProtocolBinary, ProtocolCompact, ProtocolJSON} {
+		protocolName := protocolName
+
+		t.Run(string(protocolName), func(t *testing.T) {
+			address := startTestMockServer(t, serviceTree, TransportFramed, protocolName, &MockResponse{
+				FunctionID:    testFunction(t, serviceTree, "greet").id,
+				ExceptionName: "notFound",
+				Body:          `{"message": "no greeting"}`,
+			}, nil)
+
+			form := &Form{Transport: TransportFramed, Protocol: protocolName}
+
+			response := sendTestRequest(t, form, serviceTree, "greet", address, `{"name": "nobody"}`)
+			if response.Status != ResponseStatusException {
+				t.Fatalf("unexpected response status %q", response.Status)
+			}
+
+			if response.Exception.Name != "notFound" || response.Exception.Type != "NotFound" {
+				t.Fatalf("unexpected exception %+v", response.Exception)
+			}
+
+			assertJSONEqual(t, map[string]interface{}{"message": "no greeting"}, response.Exception.Fields)
+		})
+	}
+}
+
+func TestFormSendRequestOneWay(t *testing.T) {
+	serviceTree := newTestServiceTree(t)
+
+	for _, transport := range []Transport{TransportHTTP, TransportFramed, TransportBuffered} {
+		transport := transport
+
+		t.Run(string(transport), func(t *testing.T) {
+			calls := make(chan *MockCall, 1)
+			address := startTestMockServer(t, serviceTree, transport, ProtocolBinary, nil, calls)
+
+			form := &Form{Transport: transport, Protocol: ProtocolBinary}
+
+			response := sendTestRequest(t, form, serviceTree, "notify", address, `{"name": "multibase"}`)
+			if response.Status != ResponseStatusOneWay {
+				t.Fatalf("unexpected response status %q", response.Status)
+			}
+
+			assertJSONEqual(t, map[string]interface{}{"name": "multibase"}, (<-calls).Request)
+		})
+	}
+}
+
+func TestFormSendRequestUndecodableResponse(t *testing.T) {
+	serviceTree := newTestServiceTree(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte{0x80, 0x01, 0x00})
+	}))
+	t.Cleanup(server.Close)
+
+	form := &Form{Transport: TransportHTTP, Protocol: ProtocolBinary, IsDebug: true}
+	t.Cleanup(func() {
+		_ = form.Close()
+	})
+
+	function := testFunction(t, serviceTree, "greet")
+	call := &resolvedCall{
+		functionID: function.id,
+		address:    strings.TrimPrefix(server.URL, "http://"),
+		payload:    `{"name": "multibase"}`,
+	}
+
+	responseJSON, err := form.SendRequest(form.newRequest(call, function))
+	if err == nil {
+		t.Fatal("expected a decode error")
+	}
+
+	response := &ResponseJSON{}
+
+	err = json.Unmarshal([]byte(responseJSON), response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Error == "" || response.HTTP == nil || response.HTTP.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected response %s", responseJSON)
+	}
+
+	if response.Debug == nil || response.Debug.Response == nil || response.Debug.Response.Hex != "800100" {
+		t.Fatalf("expected the response dump to be kept, got %s", responseJSON)
+	}
+}
+
+func newTestServiceTree(t *testing.T) *ServiceTree {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "greeter.thrift")
+
+	err := os.WriteFile(filePath, []byte(testIDL), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := compileFiles(nil, []string{filePath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceTree, err := NewServiceTree(modules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return serviceTree
+}
+
+func testFunction(t *testing.T, serviceTree *ServiceTree, functionName string) *ServiceTreeFunction {
+	t.Helper()
+
+	for id, function := range serviceTree.functionsByIDs {
+		if strings.HasSuffix(id, "_"+functionName) {
+			return function
+		}
+	}
+
+	t.Fatalf("function %s not found", functionName)
+
+	return nil
+}
+
+// startTestMockServer runs a mock server replying with the response, it reports the calls to the channel.
+func startTestMockServer(
+	t *testing.T,
+	serviceTree *ServiceTree,
+	transport Transport,
+	protocolName Protocol,
+	response *MockResponse,
+	calls chan<- *MockCall,
+) string {
+	t.Helper()
+
+	mockServer := newMockServer()
+
+	if response != nil {
+		mockServer.SetResponse(response)
+	}
+
+	err := mockServer.Start("127.0.0.1:0", transport, protocolName, serviceTree, func(call *MockCall) {
+		if calls != nil {
+			calls <- call
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = mockServer.Close()
+	})
+
+	return mockServer.listener.Addr().String()
+}
+
+func greetResponse(t *testing.T, serviceTree *ServiceTree) *MockResponse {
+	t.Helper()
+
+	return &MockResponse{
+		FunctionID: testFunction(t, serviceTree, "greet").id,
+		Body:       `{"text": "hello multibase", "count": 2, "tags": ["a", "b"]}`,
+	}
+}
+
+func sendTestRequest(
+	t *testing.T,
+	form *Form,
+	serviceTree *ServiceTree,
+	functionName,
+	address,
+	payload string,
+) *ResponseJSON {
+	t.Helper()
+
+	t.Cleanup(func() {
+		_ = form.Close()
+	})
+
+	function := testFunction(t, serviceTree, functionName)
+
+	request := form.newRequest(&resolvedCall{functionID: function.id, address: address, payload: payload}, function)
+
+	responseJSON, err := form.SendRequest(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := &ResponseJSON{}
+
+	err = json.Unmarshal([]byte(responseJSON), response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response
+}
+
+// assertJSONEqual compares the actual value, either decoded or a JSON text, with the expected one.
+func assertJSONEqual(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+
+	if text, ok := actual.(string); ok {
+		err := json.Unmarshal([]byte(text), &actual)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedJSON, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actualJSON, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(expectedJSON) != string(actualJSON) {
+		t.Fatalf("expected %s, got %s", expectedJSON, actualJSON)
+	}
+}
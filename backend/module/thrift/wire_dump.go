@@ -0,0 +1,798 @@
+package thrift
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/wire"
+)
+
+type PayloadMode string
+
+const (
+	PayloadModeStructured PayloadMode = "structured"
+	PayloadModeHex        PayloadMode = "hex"
+	PayloadModeBase64     PayloadMode = "base64"
+)
+
+const (
+	wireDumpSegmentLimit = 10000
+	wireDumpLabelLimit   = 64
+	binaryVersionMask    = 0xffff0000
+	binaryVersion1       = 0x80010000
+)
+
+var (
+	errUnknownPayloadMode = errors.New("unknown payload mode")
+	errInvalidRawPayload  = errors.New("invalid raw payload")
+	errWireDumpTruncated  = errors.New("message is truncated")
+	errWireDumpTooLarge   = errors.New("message has too many segments to annotate")
+	errWireDumpType       = errors.New("unknown thrift type")
+)
+
+var envelopeTypeNames = map[wire.EnvelopeType]string{
+	wire.Call:      "CALL",
+	wire.Reply:     "REPLY",
+	wire.Exception: "EXCEPTION",
+	wire.OneWay:    "ONEWAY",
+}
+
+// WireDump is a debug view of a message as it went over the wire, before transport framing.
+// A reconstructed dump shows a message rebuilt from what the transport exchanged rather than the exact bytes.
+type WireDump struct {
+	Size            int            `json:"size"`
+	Hex             string         `json:"hex"`
+	Dump            string         `json:"dump"`
+	Segments        []*WireSegment `json:"segments"`
+	Error           string         `json:"error,omitempty"`
+	IsReconstructed bool           `json:"isReconstructed,omitempty"`
+}
+
+type WireSegment struct {
+	Offset int    `json:"offset"`
+	Hex    string `json:"hex"`
+	Depth  int    `json:"depth"`
+	Label  string `json:"label"`
+}
+
+func validatePayloadMode(payloadMode PayloadMode) error {
+	switch payloadMode {
+	case PayloadModeStructured, PayloadModeHex, PayloadModeBase64, "":
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errUnknownPayloadMode, payloadMode)
+	}
+}
+
+func isRawPayloadMode(payloadMode PayloadMode) bool {
+	return payloadMode == PayloadModeHex || payloadMode == PayloadModeBase64
+}
+
+// decodeRawPayload turns user supplied hex or base64 text into bytes, ignoring whitespace
+// and the separators hex dumps are usually copied with.
+func decodeRawPayload(payloadMode PayloadMode, payload string) ([]byte, error) {
+	payload = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, payload)
+
+	switch payloadMode {
+	case PayloadModeHex:
+		payload = strings.NewReplacer("0x", "", ":", "", "-", "").Replace(payload)
+
+		message, err := hex.DecodeString(payload)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidRawPayload, err)
+		}
+
+		return message, nil
+	case PayloadModeBase64:
+		message, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			message, err = base64.RawStdEncoding.DecodeString(payload)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidRawPayload, err)
+		}
+
+		return message, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownPayloadMode, payloadMode)
+	}
+}
+
+// newWireDump annotates a message with its envelope header, field ids and types.
+// JSON messages are readable as they are, so only the binary and compact protocols get segments.
+func newWireDump(protocolName Protocol, function *compile.FunctionSpec, message []byte) *WireDump {
+	dump := &WireDump{
+		Size: len(message),
+		Hex:  hex.EncodeToString(message),
+		Dump: hex.Dump(message),
+	}
+
+	annotator := &wireAnnotator{message: message, function: function}
+
+	var err error
+
+	switch protocolName {
+	case ProtocolBinary, "":
+		err = annotator.binaryEnvelope()
+	case ProtocolCompact:
+		err = annotator.compactEnvelope()
+	default:
+		return dump
+	}
+
+	if err == nil && annotator.offset < len(message) {
+		annotator.segments = append(annotator.segments, &WireSegment{
+			Offset: annotator.offset,
+			Hex:    hex.EncodeToString(message[annotator.offset:]),
+			Label:  "trailing bytes",
+		})
+	}
+
+	dump.Segments = annotator.segments
+
+	if err != nil {
+		dump.Error = err.Error()
+	}
+
+	return dump
+}
+
+type wireAnnotator struct {
+	message  []byte
+	offset   int
+	segments []*WireSegment
+	function *compile.FunctionSpec
+}
+
+func (a *wireAnnotator) take(size, depth int, label string) ([]byte, error) {
+	if size < 0 || a.offset+size > len(a.message) {
+		return nil, fmt.Errorf("%w at offset %d: %s", errWireDumpTruncated, a.offset, label)
+	}
+
+	if len(a.segments) >= wireDumpSegmentLimit {
+		return nil, errWireDumpTooLarge
+	}
+
+	data := a.message[a.offset : a.offset+size]
+
+	a.segments = append(a.segments, &WireSegment{
+		Offset: a.offset,
+		Hex:    hex.EncodeToString(data),
+		Depth:  depth,
+		Label:  label,
+	})
+	a.offset += size
+
+	return data, nil
+}
+
+// peek returns the unread part of the message for the decoders that need a reader.
+func (a *wireAnnotator) peek() *bytes.Reader {
+	return bytes.NewReader(a.message[a.offset:])
+}
+
+func (a *wireAnnotator) remaining() int {
+	return len(a.message) - a.offset
+}
+
+// nolint: cyclop
+func (a *wireAnnotator) binaryEnvelope() error {
+	if a.remaining() < 4 { // nolint: gomnd
+		return fmt.Errorf("%w at offset %d: message header", errWireDumpTruncated, a.offset)
+	}
+
+	var envelopeType wire.EnvelopeType
+
+	version := binary.BigEndian.Uint32(a.message)
+	isStrict := version&binaryVersionMask == binaryVersion1
+
+	if isStrict {
+		envelopeType = wire.EnvelopeType(version & 0xff) // nolint: gomnd
+
+		label := fmt.Sprintf("version 1, type %s", envelopeTypeName(envelopeType))
+
+		_, err := a.take(4, 0, label) // nolint: gomnd
+		if err != nil {
+			return err
+		}
+	}
+
+	nameLength, err := a.take(4, 0, "method name length") // nolint: gomnd
+	if err != nil {
+		return err
+	}
+
+	name, err := a.take(int(int32(binary.BigEndian.Uint32(nameLength))), 0, "method name")
+	if err != nil {
+		return err
+	}
+
+	a.segments[len(a.segments)-1].Label = fmt.Sprintf("method name %q", name)
+
+	if !isStrict {
+		typeByte, err := a.take(1, 0, "message type")
+		if err != nil {
+			return err
+		}
+
+		envelopeType = wire.EnvelopeType(typeByte[0])
+		a.segments[len(a.segments)-1].Label = fmt.Sprintf("type %s", envelopeTypeName(envelopeType))
+	}
+
+	seqID, err := a.take(4, 0, "sequence id") // nolint: gomnd
+	if err != nil {
+		return err
+	}
+
+	a.segments[len(a.segments)-1].Label = fmt.Sprintf("sequence id %d", int32(binary.BigEndian.Uint32(seqID)))
+
+	return a.binaryStruct(0, envelopeSpec(a.function, envelopeType))
+}
+
+func (a *wireAnnotator) binaryStruct(depth int, spec compile.TypeSpec) error {
+	for {
+		typeByte, err := a.take(1, depth, "stop")
+		if err != nil {
+			return err
+		}
+
+		if typeByte[0] == 0 {
+			return nil
+		}
+
+		if a.remaining() < 2 { // nolint: gomnd
+			return fmt.Errorf("%w at offset %d: field id", errWireDumpTruncated, a.offset)
+		}
+
+		fieldID := int16(binary.BigEndian.Uint16(a.message[a.offset:]))
+		fieldType := wire.Type(typeByte[0])
+		fieldSpec := structField(spec, fieldID)
+
+		// the type byte and the field id are shown as one field header
+		segment := a.segments[len(a.segments)-1]
+		segment.Hex += hex.EncodeToString(a.message[a.offset : a.offset+2])
+		segment.Label = fieldLabel(fieldID, fieldType, fieldSpec)
+		a.offset += 2
+
+		if err := a.binaryValue(depth+1, fieldType, fieldTypeSpec(fieldSpec), ""); err != nil {
+			return err
+		}
+	}
+}
+
+// nolint: cyclop, funlen
+func (a *wireAnnotator) binaryValue(depth int, valueType wire.Type, spec compile.TypeSpec, prefix string) error {
+	switch valueType {
+	case wire.TBool, wire.TI8:
+		data, err := a.take(1, depth, prefix+wireTypeName(valueType))
+		if err != nil {
+			return err
+		}
+
+		if valueType == wire.TBool {
+			a.segments[len(a.segments)-1].Label = prefix + strconv.FormatBool(data[0] == 1)
+		} else {
+			a.segments[len(a.segments)-1].Label = prefix + enumLabel(spec, int64(int8(data[0])))
+		}
+
+		return nil
+	case wire.TI16:
+		data, err := a.take(2, depth, prefix+"i16") // nolint: gomnd
+		if err != nil {
+			return err
+		}
+
+		value := int16(binary.BigEndian.Uint16(data))
+		a.segments[len(a.segments)-1].Label = prefix + enumLabel(spec, int64(value))
+
+		return nil
+	case wire.TI32:
+		data, err := a.take(4, depth, prefix+"i32") // nolint: gomnd
+		if err != nil {
+			return err
+		}
+
+		value := int32(binary.BigEndian.Uint32(data))
+		a.segments[len(a.segments)-1].Label = prefix + enumLabel(spec, int64(value))
+
+		return nil
+	case wire.TI64:
+		data, err := a.take(8, depth, prefix+"i64") // nolint: gomnd
+		if err != nil {
+			return err
+		}
+
+		a.segments[len(a.segments)-1].Label = prefix + enumLabel(spec, int64(binary.BigEndian.Uint64(data)))
+
+		return nil
+	case wire.TDouble:
+		data, err := a.take(8, depth, prefix+"double") // nolint: gomnd
+		if err != nil {
+			return err
+		}
+
+		value := math.Float64frombits(binary.BigEndian.Uint64(data))
+		a.segments[len(a.segments)-1].Label = prefix + strconv.FormatFloat(value, 'g', -1, 64)
+
+		return nil
+	case wire.TBinary:
+		length, err := a.take(4, depth, prefix+"length") // nolint: gomnd
+		if err != nil {
+			return err
+		}
+
+		size := int(int32(binary.BigEndian.Uint32(length)))
+		a.segments[len(a.segments)-1].Label = fmt.Sprintf("%slength %d", prefix, size)
+
+		data, err := a.take(size, depth, "bytes")
+		if err != nil {
+			return err
+		}
+
+		a.segments[len(a.segments)-1].Label = binaryLabel(data)
+
+		return nil
+	case wire.TStruct:
+		return a.binaryStruct(depth, spec)
+	case wire.TMap:
+		header, err := a.take(6, depth, prefix+"map header") // nolint: gomnd
+		if err != nil {
+			return err
+		}
+
+		keyType, valueType := wire.Type(header[0]), wire.Type(header[1])
+		size := int(int32(binary.BigEndian.Uint32(header[2:])))
+		a.segments[len(a.segments)-1].Label = fmt.Sprintf(
+			"%smap<%s, %s> size %d", prefix, wireTypeName(keyType), wireTypeName(valueType), size,
+		)
+
+		if size < 0 || size > a.remaining() {
+			return fmt.Errorf("%w at offset %d: map of %d items", errWireDumpTruncated, a.offset, size)
+		}
+
+		keySpec, valueSpec := mapSpecs(spec)
+
+		for i := 0; i < size; i++ {
+			keyPrefix, valuePrefix := fmt.Sprintf("key %d: ", i), fmt.Sprintf("value %d: ", i)
+
+			if err := a.binaryValue(depth+1, keyType, keySpec, keyPrefix); err != nil {
+				return err
+			}
+
+			if err := a.binaryValue(depth+1, valueType, valueSpec, valuePrefix); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case wire.TSet, wire.TList:
+		header, err := a.take(5, depth, prefix+"list header") // nolint: gomnd
+		if err != nil {
+			return err
+		}
+
+		elementType := wire.Type(header[0])
+		size := int(int32(binary.BigEndian.Uint32(header[1:])))
+		a.segments[len(a.segments)-1].Label = fmt.Sprintf(
+			"%s%s<%s> size %d", prefix, wireTypeName(valueType), wireTypeName(elementType), size,
+		)
+
+		if size < 0 || size > a.remaining() {
+			return fmt.Errorf("%w at offset %d: list of %d items", errWireDumpTruncated, a.offset, size)
+		}
+
+		elementSpec := listSpec(spec)
+
+		for i := 0; i < size; i++ {
+			elementPrefix := fmt.Sprintf("[%d] ", i)
+			if err := a.binaryValue(depth+1, elementType, elementSpec, elementPrefix); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w at offset %d: %d", errWireDumpType, a.offset, valueType)
+	}
+}
+
+func (a *wireAnnotator) compactEnvelope() error {
+	if _, err := a.take(1, 0, "protocol id"); err != nil {
+		return err
+	}
+
+	if a.message[0] != compactProtocolID {
+		return fmt.Errorf("%w: %#x", errCompactProtocolID, a.message[0])
+	}
+
+	versionAndType, err := a.take(1, 0, "version and type")
+	if err != nil {
+		return err
+	}
+
+	envelopeType := wire.EnvelopeType((versionAndType[0] >> compactTypeShiftAmount) & compactTypeMask)
+	a.segments[len(a.segments)-1].Label = fmt.Sprintf(
+		"version %d, type %s", versionAndType[0]&compactVersionMask, envelopeTypeName(envelopeType),
+	)
+
+	reader := a.peek()
+
+	seqID, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return fmt.Errorf("%w at offset %d: sequence id", errWireDumpTruncated, a.offset)
+	}
+
+	if _, err := a.take(a.remaining()-reader.Len(), 0, fmt.Sprintf("sequence id %d", int32(seqID))); err != nil {
+		return err
+	}
+
+	name, err := a.compactBinary(0, "method name length")
+	if err != nil {
+		return err
+	}
+
+	a.segments[len(a.segments)-1].Label = fmt.Sprintf("method name %q", name)
+
+	return a.compactStruct(0, envelopeSpec(a.function, envelopeType))
+}
+
+func (a *wireAnnotator) compactStruct(depth int, spec compile.TypeSpec) error {
+	var lastFieldID int16
+
+	for {
+		start := a.offset
+
+		header, err := a.take(1, depth, "stop")
+		if err != nil {
+			return err
+		}
+
+		if header[0] == compactTypeStop {
+			return nil
+		}
+
+		compactType := header[0] & 0x0f // nolint: gomnd
+		fieldID := lastFieldID + int16(header[0]>>4)
+
+		if header[0]>>4 == 0 {
+			reader := a.peek()
+
+			id, err := readCompactVarint(reader)
+			if err != nil {
+				return fmt.Errorf("%w at offset %d: field id", errWireDumpTruncated, a.offset)
+			}
+
+			fieldID = int16(id)
+			a.offset += a.remaining() - reader.Len()
+		}
+
+		lastFieldID = fieldID
+		fieldType := wireTypeFromCompact(compactType)
+		fieldSpec := structField(spec, fieldID)
+
+		segment := a.segments[len(a.segments)-1]
+		segment.Hex = hex.EncodeToString(a.message[start:a.offset])
+		segment.Label = fieldLabel(fieldID, fieldType, fieldSpec)
+
+		if compactType == compactTypeBooleanTrue || compactType == compactTypeBooleanFalse {
+			segment.Label += fmt.Sprintf(" = %t", compactType == compactTypeBooleanTrue)
+
+			continue
+		}
+
+		if err := a.compactValue(depth+1, compactType, fieldTypeSpec(fieldSpec), ""); err != nil {
+			return err
+		}
+	}
+}
+
+// nolint: cyclop, funlen
+func (a *wireAnnotator) compactValue(depth int, compactType byte, spec compile.TypeSpec, prefix string) error {
+	switch compactType {
+	case compactTypeBooleanTrue, compactTypeBooleanFalse:
+		data, err := a.take(1, depth, prefix+"bool")
+		if err != nil {
+			return err
+		}
+
+		a.segments[len(a.segments)-1].Label = prefix + strconv.FormatBool(data[0] == compactTypeBooleanTrue)
+
+		return nil
+	case compactTypeByte:
+		data, err := a.take(1, depth, prefix+"byte")
+		if err != nil {
+			return err
+		}
+
+		a.segments[len(a.segments)-1].Label = prefix + enumLabel(spec, int64(int8(data[0])))
+
+		return nil
+	case compactTypeI16, compactTypeI32, compactTypeI64:
+		reader := a.peek()
+
+		value, err := readCompactVarint(reader)
+		if err != nil {
+			return fmt.Errorf("%w at offset %d: varint", errWireDumpTruncated, a.offset)
+		}
+
+		_, err = a.take(a.remaining()-reader.Len(), depth, prefix+enumLabel(spec, value))
+
+		return err
+	case compactTypeDouble:
+		data, err := a.take(8, depth, prefix+"double") // nolint: gomnd
+		if err != nil {
+			return err
+		}
+
+		value := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		a.segments[len(a.segments)-1].Label = prefix + strconv.FormatFloat(value, 'g', -1, 64)
+
+		return nil
+	case compactTypeBinary:
+		data, err := a.compactBinary(depth, prefix+"length")
+		if err != nil {
+			return err
+		}
+
+		a.segments[len(a.segments)-1].Label = binaryLabel(data)
+
+		return nil
+	case compactTypeStruct:
+		return a.compactStruct(depth, spec)
+	case compactTypeMap:
+		return a.compactMap(depth, spec, prefix)
+	case compactTypeList, compactTypeSet:
+		header, err := a.take(1, depth, prefix+"list header")
+		if err != nil {
+			return err
+		}
+
+		start := a.offset - 1
+		elementType := header[0] & 0x0f // nolint: gomnd
+		size := int(header[0] >> 4)
+
+		if size == 0x0f { // nolint: gomnd
+			reader := a.peek()
+
+			longSize, err := binary.ReadUvarint(reader)
+			if err != nil {
+				return fmt.Errorf("%w at offset %d: list size", errWireDumpTruncated, a.offset)
+			}
+
+			size = int(longSize)
+			a.offset += a.remaining() - reader.Len()
+		}
+
+		segment := a.segments[len(a.segments)-1]
+		segment.Hex = hex.EncodeToString(a.message[start:a.offset])
+		segment.Label = fmt.Sprintf(
+			"%s%s<%s> size %d",
+			prefix,
+			wireTypeName(wireTypeFromCompact(compactType)),
+			wireTypeName(wireTypeFromCompact(elementType)),
+			size,
+		)
+
+		if size < 0 || size > a.remaining() {
+			return fmt.Errorf("%w at offset %d: list of %d items", errWireDumpTruncated, a.offset, size)
+		}
+
+		elementSpec := listSpec(spec)
+
+		for i := 0; i < size; i++ {
+			elementPrefix := fmt.Sprintf("[%d] ", i)
+			if err := a.compactValue(depth+1, elementType, elementSpec, elementPrefix); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %d", errCompactType, compactType)
+	}
+}
+
+func (a *wireAnnotator) compactMap(depth int, spec compile.TypeSpec, prefix string) error {
+	reader := a.peek()
+
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return fmt.Errorf("%w at offset %d: map size", errWireDumpTruncated, a.offset)
+	}
+
+	if int(size) < 0 || int(size) > a.remaining() {
+		return fmt.Errorf("%w at offset %d: map of %d items", errWireDumpTruncated, a.offset, size)
+	}
+
+	if size == 0 {
+		_, err := a.take(a.remaining()-reader.Len(), depth, prefix+"map size 0")
+
+		return err
+	}
+
+	types, err := a.take(a.remaining()-reader.Len()+1, depth, prefix+"map header")
+	if err != nil {
+		return err
+	}
+
+	keyType, valueType := types[len(types)-1]>>4, types[len(types)-1]&0x0f // nolint: gomnd
+	a.segments[len(a.segments)-1].Label = fmt.Sprintf(
+		"%smap<%s, %s> size %d",
+		prefix, wireTypeName(wireTypeFromCompact(keyType)), wireTypeName(wireTypeFromCompact(valueType)), size,
+	)
+
+	keySpec, valueSpec := mapSpecs(spec)
+
+	for i := 0; i < int(size); i++ {
+		if err := a.compactValue(depth+1, keyType, keySpec, fmt.Sprintf("key %d: ", i)); err != nil {
+			return err
+		}
+
+		if err := a.compactValue(depth+1, valueType, valueSpec, fmt.Sprintf("value %d: ", i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compactBinary annotates a varint length followed by the bytes and returns the bytes.
+func (a *wireAnnotator) compactBinary(depth int, label string) ([]byte, error) {
+	reader := a.peek()
+
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w at offset %d: %s", errWireDumpTruncated, a.offset, label)
+	}
+
+	if _, err := a.take(a.remaining()-reader.Len(), depth, fmt.Sprintf("%s %d", label, size)); err != nil {
+		return nil, err
+	}
+
+	if size > uint64(a.remaining()) {
+		return nil, fmt.Errorf("%w at offset %d: %d bytes", errWireDumpTruncated, a.offset, size)
+	}
+
+	return a.take(int(size), depth, "bytes")
+}
+
+func structField(spec compile.TypeSpec, fieldID int16) *compile.FieldSpec {
+	structSpec, ok := rootTypeSpec(spec).(*compile.StructSpec)
+	if !ok {
+		return nil
+	}
+
+	for _, field := range structSpec.Fields {
+		if field.ID == fieldID {
+			return field
+		}
+	}
+
+	return nil
+}
+
+func fieldTypeSpec(field *compile.FieldSpec) compile.TypeSpec {
+	if field == nil {
+		return nil
+	}
+
+	return field.Type
+}
+
+func mapSpecs(spec compile.TypeSpec) (compile.TypeSpec, compile.TypeSpec) {
+	mapSpec, ok := rootTypeSpec(spec).(*compile.MapSpec)
+	if !ok {
+		return nil, nil
+	}
+
+	return mapSpec.KeySpec, mapSpec.ValueSpec
+}
+
+func listSpec(spec compile.TypeSpec) compile.TypeSpec {
+	switch typedSpec := rootTypeSpec(spec).(type) {
+	case *compile.ListSpec:
+		return typedSpec.ValueSpec
+	case *compile.SetSpec:
+		return typedSpec.ValueSpec
+	default:
+		return nil
+	}
+}
+
+func rootTypeSpec(spec compile.TypeSpec) compile.TypeSpec {
+	if spec == nil {
+		return nil
+	}
+
+	return compile.RootTypeSpec(spec)
+}
+
+func fieldLabel(fieldID int16, fieldType wire.Type, field *compile.FieldSpec) string {
+	if field == nil {
+		return fmt.Sprintf("field %d (%s)", fieldID, wireTypeName(fieldType))
+	}
+
+	return fmt.Sprintf("field %d %s (%s)", fieldID, field.Name, field.Type.ThriftName())
+}
+
+func enumLabel(spec compile.TypeSpec, value int64) string {
+	enumSpec, ok := rootTypeSpec(spec).(*compile.EnumSpec)
+	if !ok {
+		return strconv.FormatInt(value, 10)
+	}
+
+	for _, item := range enumSpec.Items {
+		if int64(item.Value) == value {
+			return fmt.Sprintf("%d (%s)", value, item.Name)
+		}
+	}
+
+	return strconv.FormatInt(value, 10)
+}
+
+func binaryLabel(data []byte) string {
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("%d bytes", len(data))
+	}
+
+	text := string(data)
+	if utf8.RuneCountInString(text) > wireDumpLabelLimit {
+		text = string([]rune(text)[:wireDumpLabelLimit]) + "..."
+	}
+
+	return strconv.Quote(text)
+}
+
+func wireTypeName(wireType wire.Type) string {
+	switch wireType {
+	case wire.TBool:
+		return "bool"
+	case wire.TI8:
+		return "byte"
+	case wire.TDouble:
+		return "double"
+	case wire.TI16:
+		return "i16"
+	case wire.TI32:
+		return "i32"
+	case wire.TI64:
+		return "i64"
+	case wire.TBinary:
+		return "binary"
+	case wire.TStruct:
+		return "struct"
+	case wire.TMap:
+		return "map"
+	case wire.TSet:
+		return "set"
+	case wire.TList:
+		return "list"
+	default:
+		return fmt.Sprintf("unknown(%d)", wireType)
+	}
+}
+
+func envelopeTypeName(envelopeType wire.EnvelopeType) string {
+	if name, ok := envelopeTypeNames[envelopeType]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("UNKNOWN(%d)", envelopeType)
+}
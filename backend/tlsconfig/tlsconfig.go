@@ -0,0 +1,63 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	errInvalidCACert  = errors.New("no certificates found in the ca file")
+	errIncompleteCert = errors.New("both client certificate and key must be provided")
+)
+
+// Settings are the certificate files and checks a TLS connection of a module is made with.
+type Settings struct {
+	CACertPath         string `json:"caCertPath"`
+	ClientCertPath     string `json:"clientCertPath"`
+	ClientKeyPath      string `json:"clientKeyPath"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+}
+
+func (s *Settings) Config() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if s == nil {
+		return config, nil
+	}
+
+	config.InsecureSkipVerify = s.InsecureSkipVerify // nolint: gosec
+
+	if s.CACertPath != "" {
+		caCert, err := os.ReadFile(s.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read a ca file: %w", err)
+		}
+
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("%w: %s", errInvalidCACert, s.CACertPath)
+		}
+
+		config.RootCAs = certPool
+	}
+
+	if s.ClientCertPath != "" || s.ClientKeyPath != "" {
+		if s.ClientCertPath == "" || s.ClientKeyPath == "" {
+			return nil, errIncompleteCert
+		}
+
+		certificate, err := tls.LoadX509KeyPair(s.ClientCertPath, s.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load a client certificate: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
@@ -5,6 +5,8 @@ export function AddHeader(arg1:string,arg2:string):Promise<any>;
 
 export function BeautifyRequest(arg1:string,arg2:string):Promise<any>;
 
+export function ClearProxyHistory(arg1:string):Promise<any>;
+
 export function CreateNewForm(arg1:string):Promise<any>;
 
 export function CreateNewProject(arg1:string):Promise<any>;
@@ -15,6 +17,8 @@ export function DeleteHeader(arg1:string,arg2:string,arg3:string):Promise<any>;
 
 export function DeleteProject(arg1:string):Promise<void>;
 
+export function OpenBufWorkspace(arg1:string):Promise<any>;
+
 export function OpenImportPath(arg1:string):Promise<any>;
 
 export function OpenProtoFile(arg1:string):Promise<any>;
@@ -27,6 +31,8 @@ export function RemoveForm(arg1:string,arg2:string):Promise<any>;
 
 export function RemoveImportPath(arg1:string,arg2:string):Promise<any>;
 
+export function ReplayProxyEntry(arg1:string,arg2:string,arg3:string):Promise<any>;
+
 export function SaveAddress(arg1:string,arg2:string,arg3:string):Promise<any>;
 
 export function SaveCurrentFormID(arg1:string,arg2:string):Promise<any>;
@@ -41,4 +47,8 @@ export function SelectMethod(arg1:string,arg2:string,arg3:string):Promise<any>;
 
 export function SendRequest(arg1:string,arg2:string,arg3:string,arg4:string):Promise<any>;
 
+export function StartProxy(arg1:string,arg2:string,arg3:string):Promise<any>;
+
+export function StopProxy(arg1:string):Promise<any>;
+
 export function StopRequest(arg1:string,arg2:string):Promise<any>;
@@ -10,6 +10,10 @@ export function BeautifyRequest(arg1, arg2) {
   return window['go']['grpc']['Module']['BeautifyRequest'](arg1, arg2);
 }
 
+export function ClearProxyHistory(arg1) {
+  return window['go']['grpc']['Module']['ClearProxyHistory'](arg1);
+}
+
 export function CreateNewForm(arg1) {
   return window['go']['grpc']['Module']['CreateNewForm'](arg1);
 }
@@ -30,6 +34,10 @@ export function DeleteProject(arg1) {
   return window['go']['grpc']['Module']['DeleteProject'](arg1);
 }
 
+export function OpenBufWorkspace(arg1) {
+  return window['go']['grpc']['Module']['OpenBufWorkspace'](arg1);
+}
+
 export function OpenImportPath(arg1) {
   return window['go']['grpc']['Module']['OpenImportPath'](arg1);
 }
@@ -54,6 +62,10 @@ export function RemoveImportPath(arg1, arg2) {
   return window['go']['grpc']['Module']['RemoveImportPath'](arg1, arg2);
 }
 
+export function ReplayProxyEntry(arg1, arg2, arg3) {
+  return window['go']['grpc']['Module']['ReplayProxyEntry'](arg1, arg2, arg3);
+}
+
 export function SaveAddress(arg1, arg2, arg3) {
   return window['go']['grpc']['Module']['SaveAddress'](arg1, arg2, arg3);
 }
@@ -82,6 +94,14 @@ export function SendRequest(arg1, arg2, arg3, arg4) {
   return window['go']['grpc']['Module']['SendRequest'](arg1, arg2, arg3, arg4);
 }
 
+export function StartProxy(arg1, arg2, arg3) {
+  return window['go']['grpc']['Module']['StartProxy'](arg1, arg2, arg3);
+}
+
+export function StopProxy(arg1) {
+  return window['go']['grpc']['Module']['StopProxy'](arg1);
+}
+
 export function StopRequest(arg1, arg2) {
   return window['go']['grpc']['Module']['StopRequest'](arg1, arg2);
 }
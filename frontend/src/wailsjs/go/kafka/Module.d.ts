@@ -2,24 +2,74 @@
 // This file is automatically generated. DO NOT EDIT
 import {kafka} from '../models';
 
+export function AddTopicPartitions(arg1:string,arg2:string,arg3:number):Promise<any>;
+
+export function AlterTopicConfigs(arg1:string,arg2:string,arg3:Array<any>):Promise<any>;
+
+export function BrokerDetails(arg1:string,arg2:number):Promise<any>;
+
 export function Brokers(arg1:string):Promise<any>;
 
 export function Close():Promise<void>;
 
 export function Connect(arg1:string):Promise<any>;
 
+export function ConsumerGroup(arg1:string,arg2:string):Promise<any>;
+
 export function Consumers(arg1:string):Promise<any>;
 
 export function CreateNewProject(arg1:string):Promise<any>;
 
+export function CreateTopic(arg1:string,arg2:any):Promise<any>;
+
+export function DeleteAllProtoFiles(arg1:string):Promise<any>;
+
+export function DeleteConsumerGroup(arg1:string,arg2:string,arg3:string):Promise<any>;
+
+export function DeleteConsumerGroupOffsets(arg1:string,arg2:string,arg3:string,arg4:string):Promise<any>;
+
 export function DeleteProject(arg1:string):Promise<void>;
 
+export function DeleteTopic(arg1:string,arg2:string):Promise<any>;
+
+export function OpenProtoDescriptorSet(arg1:string):Promise<any>;
+
+export function OpenProtoFile(arg1:string):Promise<any>;
+
+export function OpenProtoImportPath(arg1:string):Promise<any>;
+
+export function Overview(arg1:string):Promise<any>;
+
+export function ProduceMessage(arg1:string,arg2:string,arg3:any,arg4:any):Promise<any>;
+
+export function ProduceMessages(arg1:string,arg2:string,arg3:Array<any>,arg4:any):Promise<any>;
+
+export function ProduceMessagesFromFile(arg1:string,arg2:string,arg3:any):Promise<any>;
+
 export function ProjectState(arg1:string):Promise<any>;
 
+export function ProtoMessageTypes(arg1:string):Promise<Array<string>>;
+
+export function RemoveProtoImportPath(arg1:string,arg2:string):Promise<any>;
+
+export function ResetConsumerGroupOffsets(arg1:string,arg2:string,arg3:any,arg4:string):Promise<any>;
+
 export function SaveState(arg1:string,arg2:any):Promise<any>;
 
-export function StartTopicConsuming(arg1:string,arg2:kafka.TopicConsumingStrategy,arg3:string,arg4:string,arg5:number):Promise<any>;
+export function SaveTopicProtoMapping(arg1:string,arg2:any):Promise<any>;
+
+export function StartConsumerGroupWatching(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function StartTopicConsuming(arg1:string,arg2:kafka.TopicConsumingStrategy,arg3:string,arg4:string,arg5:number,arg6:any):Promise<any>;
+
+export function StopConsumerGroupWatching(arg1:string):Promise<void>;
 
 export function StopTopicConsuming(arg1:string):Promise<void>;
 
+export function TopicConfigs(arg1:string,arg2:string):Promise<any>;
+
+export function TopicDetails(arg1:string,arg2:string):Promise<any>;
+
+export function TopicMessages(arg1:string,arg2:number,arg3:number):Promise<any>;
+
 export function Topics(arg1:string):Promise<any>;
@@ -2,6 +2,18 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddTopicPartitions(arg1, arg2, arg3) {
+  return window['go']['kafka']['Module']['AddTopicPartitions'](arg1, arg2, arg3);
+}
+
+export function AlterTopicConfigs(arg1, arg2, arg3) {
+  return window['go']['kafka']['Module']['AlterTopicConfigs'](arg1, arg2, arg3);
+}
+
+export function BrokerDetails(arg1, arg2) {
+  return window['go']['kafka']['Module']['BrokerDetails'](arg1, arg2);
+}
+
 export function Brokers(arg1) {
   return window['go']['kafka']['Module']['Brokers'](arg1);
 }
@@ -14,6 +26,10 @@ export function Connect(arg1) {
   return window['go']['kafka']['Module']['Connect'](arg1);
 }
 
+export function ConsumerGroup(arg1, arg2) {
+  return window['go']['kafka']['Module']['ConsumerGroup'](arg1, arg2);
+}
+
 export function Consumers(arg1) {
   return window['go']['kafka']['Module']['Consumers'](arg1);
 }
@@ -22,26 +38,110 @@ export function CreateNewProject(arg1) {
   return window['go']['kafka']['Module']['CreateNewProject'](arg1);
 }
 
+export function CreateTopic(arg1, arg2) {
+  return window['go']['kafka']['Module']['CreateTopic'](arg1, arg2);
+}
+
+export function DeleteAllProtoFiles(arg1) {
+  return window['go']['kafka']['Module']['DeleteAllProtoFiles'](arg1);
+}
+
+export function DeleteConsumerGroup(arg1, arg2, arg3) {
+  return window['go']['kafka']['Module']['DeleteConsumerGroup'](arg1, arg2, arg3);
+}
+
+export function DeleteConsumerGroupOffsets(arg1, arg2, arg3, arg4) {
+  return window['go']['kafka']['Module']['DeleteConsumerGroupOffsets'](arg1, arg2, arg3, arg4);
+}
+
 export function DeleteProject(arg1) {
   return window['go']['kafka']['Module']['DeleteProject'](arg1);
 }
 
+export function DeleteTopic(arg1, arg2) {
+  return window['go']['kafka']['Module']['DeleteTopic'](arg1, arg2);
+}
+
+export function OpenProtoDescriptorSet(arg1) {
+  return window['go']['kafka']['Module']['OpenProtoDescriptorSet'](arg1);
+}
+
+export function OpenProtoFile(arg1) {
+  return window['go']['kafka']['Module']['OpenProtoFile'](arg1);
+}
+
+export function OpenProtoImportPath(arg1) {
+  return window['go']['kafka']['Module']['OpenProtoImportPath'](arg1);
+}
+
+export function Overview(arg1) {
+  return window['go']['kafka']['Module']['Overview'](arg1);
+}
+
+export function ProduceMessage(arg1, arg2, arg3, arg4) {
+  return window['go']['kafka']['Module']['ProduceMessage'](arg1, arg2, arg3, arg4);
+}
+
+export function ProduceMessages(arg1, arg2, arg3, arg4) {
+  return window['go']['kafka']['Module']['ProduceMessages'](arg1, arg2, arg3, arg4);
+}
+
+export function ProduceMessagesFromFile(arg1, arg2, arg3) {
+  return window['go']['kafka']['Module']['ProduceMessagesFromFile'](arg1, arg2, arg3);
+}
+
 export function ProjectState(arg1) {
   return window['go']['kafka']['Module']['ProjectState'](arg1);
 }
 
+export function ProtoMessageTypes(arg1) {
+  return window['go']['kafka']['Module']['ProtoMessageTypes'](arg1);
+}
+
+export function RemoveProtoImportPath(arg1, arg2) {
+  return window['go']['kafka']['Module']['RemoveProtoImportPath'](arg1, arg2);
+}
+
+export function ResetConsumerGroupOffsets(arg1, arg2, arg3, arg4) {
+  return window['go']['kafka']['Module']['ResetConsumerGroupOffsets'](arg1, arg2, arg3, arg4);
+}
+
 export function SaveState(arg1, arg2) {
   return window['go']['kafka']['Module']['SaveState'](arg1, arg2);
 }
 
-export function StartTopicConsuming(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['kafka']['Module']['StartTopicConsuming'](arg1, arg2, arg3, arg4, arg5);
+export function SaveTopicProtoMapping(arg1, arg2) {
+  return window['go']['kafka']['Module']['SaveTopicProtoMapping'](arg1, arg2);
+}
+
+export function StartConsumerGroupWatching(arg1, arg2, arg3) {
+  return window['go']['kafka']['Module']['StartConsumerGroupWatching'](arg1, arg2, arg3);
+}
+
+export function StartTopicConsuming(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['kafka']['Module']['StartTopicConsuming'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function StopConsumerGroupWatching(arg1) {
+  return window['go']['kafka']['Module']['StopConsumerGroupWatching'](arg1);
 }
 
 export function StopTopicConsuming(arg1) {
   return window['go']['kafka']['Module']['StopTopicConsuming'](arg1);
 }
 
+export function TopicConfigs(arg1, arg2) {
+  return window['go']['kafka']['Module']['TopicConfigs'](arg1, arg2);
+}
+
+export function TopicDetails(arg1, arg2) {
+  return window['go']['kafka']['Module']['TopicDetails'](arg1, arg2);
+}
+
+export function TopicMessages(arg1, arg2, arg3) {
+  return window['go']['kafka']['Module']['TopicMessages'](arg1, arg2, arg3);
+}
+
 export function Topics(arg1) {
   return window['go']['kafka']['Module']['Topics'](arg1);
 }
@@ -1,5 +1,21 @@
 export namespace grpc {
 	
+	export class BufWorkspace {
+	    rootPath: string;
+	    moduleRootList: string[];
+	    dependencyPathList: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BufWorkspace(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rootPath = source["rootPath"];
+	        this.moduleRootList = source["moduleRootList"];
+	        this.dependencyPathList = source["dependencyPathList"];
+	    }
+	}
 	export class Header {
 	    id: string;
 	    key: string;
@@ -16,6 +32,111 @@ export namespace grpc {
 	        this.value = source["value"];
 	    }
 	}
+	export class ResponseJSONError {
+	    code: string;
+	    message: string;
+	    details?: Array<Record<string, any>>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResponseJSONError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.message = source["message"];
+	        this.details = source["details"];
+	    }
+	}
+	export class ProxyHistoryEntry {
+	    id: string;
+	    methodID: string;
+	    upstreamAddress: string;
+	    startedAt: string;
+	    durationMs: number;
+	    requestHeaders: Header[];
+	    requests: string[];
+	    responseHeaders: {[key: string]: string[]};
+	    responseTrailers: {[key: string]: string[]};
+	    responses: string[];
+	    // Go type: ResponseJSONError
+	    status?: any;
+	    hasUndecodedRequests: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProxyHistoryEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.methodID = source["methodID"];
+	        this.upstreamAddress = source["upstreamAddress"];
+	        this.startedAt = source["startedAt"];
+	        this.durationMs = source["durationMs"];
+	        this.requestHeaders = this.convertValues(source["requestHeaders"], Header);
+	        this.requests = source["requests"];
+	        this.responseHeaders = source["responseHeaders"];
+	        this.responseTrailers = source["responseTrailers"];
+	        this.responses = source["responses"];
+	        this.status = this.convertValues(source["status"], null);
+	        this.hasUndecodedRequests = source["hasUndecodedRequests"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Proxy {
+	    listenAddress: string;
+	    upstreamAddress: string;
+	    isRunning: boolean;
+	    history: ProxyHistoryEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Proxy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.listenAddress = source["listenAddress"];
+	        this.upstreamAddress = source["upstreamAddress"];
+	        this.isRunning = source["isRunning"];
+	        this.history = this.convertValues(source["history"], ProxyHistoryEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ProtoTreeNode {
 	    id: string;
 	    label: string;
@@ -101,7 +222,9 @@ export namespace grpc {
 	    isReflected: boolean;
 	    importPathList: string[];
 	    protoFileList: string[];
+	    bufWorkspace?: BufWorkspace;
 	    nodes: ProtoTreeNode[];
+	    proxy?: Proxy;
 	
 	    static createFrom(source: any = {}) {
 	        return new Project(source);
@@ -117,7 +240,9 @@ export namespace grpc {
 	        this.isReflected = source["isReflected"];
 	        this.importPathList = source["importPathList"];
 	        this.protoFileList = source["protoFileList"];
+	        this.bufWorkspace = this.convertValues(source["bufWorkspace"], BufWorkspace);
 	        this.nodes = this.convertValues(source["nodes"], ProtoTreeNode);
+	        this.proxy = this.convertValues(source["proxy"], Proxy);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -143,62 +268,60 @@ export namespace grpc {
 
 export namespace kafka {
 	
-	export class State {
-	    id: string;
-	    address: string;
-	    authMethod: string;
-	    authUsername: string;
-	    authPassword: string;
-	    isConnected: boolean;
-	    currentTab: string;
+	export class BrokerAPIVersion {
+	    key: number;
+	    name: string;
+	    minVersion: number;
+	    maxVersion: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new State(source);
+	        return new BrokerAPIVersion(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.address = source["address"];
-	        this.authMethod = source["authMethod"];
-	        this.authUsername = source["authUsername"];
-	        this.authPassword = source["authPassword"];
-	        this.isConnected = source["isConnected"];
-	        this.currentTab = source["currentTab"];
+	        this.key = source["key"];
+	        this.name = source["name"];
+	        this.minVersion = source["minVersion"];
+	        this.maxVersion = source["maxVersion"];
 	    }
 	}
-	export class TabBrokersDataBroker {
-	    id: number;
-	    rack: string;
-	    host: string;
-	    port: number;
+	export class BrokerLogDirPartition {
+	    topic: string;
+	    partitionID: number;
+	    size: number;
+	    offsetLag: number;
+	    isFuture: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabBrokersDataBroker(source);
+	        return new BrokerLogDirPartition(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.rack = source["rack"];
-	        this.host = source["host"];
-	        this.port = source["port"];
+	        this.topic = source["topic"];
+	        this.partitionID = source["partitionID"];
+	        this.size = source["size"];
+	        this.offsetLag = source["offsetLag"];
+	        this.isFuture = source["isFuture"];
 	    }
 	}
-	export class TabBrokersData {
-	    isConnected: boolean;
-	    count: number;
-	    list: TabBrokersDataBroker[];
+	export class BrokerLogDir {
+	    path: string;
+	    size: number;
+	    partitions: BrokerLogDirPartition[];
+	    error: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabBrokersData(source);
+	        return new BrokerLogDir(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.isConnected = source["isConnected"];
-	        this.count = source["count"];
-	        this.list = this.convertValues(source["list"], TabBrokersDataBroker);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.partitions = this.convertValues(source["partitions"], BrokerLogDirPartition);
+	        this.error = source["error"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -219,34 +342,68 @@ export namespace kafka {
 		    return a;
 		}
 	}
-	export class TabConsumersDataConsumer {
+	export class ConfigEntry {
 	    name: string;
-	    state: string;
+	    value: string;
+	    defaultValue: string;
+	    source: string;
+	    isOverridden: boolean;
+	    isSensitive: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabConsumersDataConsumer(source);
+	        return new ConfigEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.state = source["state"];
+	        this.value = source["value"];
+	        this.defaultValue = source["defaultValue"];
+	        this.source = source["source"];
+	        this.isOverridden = source["isOverridden"];
+	        this.isSensitive = source["isSensitive"];
 	    }
 	}
-	export class TabConsumersData {
-	    isConnected: boolean;
-	    count: number;
-	    list: TabConsumersDataConsumer[];
+	export class TabBrokersDataBroker {
+	    id: number;
+	    rack: string;
+	    host: string;
+	    port: number;
+	    isController: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabConsumersData(source);
+	        return new TabBrokersDataBroker(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.isConnected = source["isConnected"];
-	        this.count = source["count"];
-	        this.list = this.convertValues(source["list"], TabConsumersDataConsumer);
+	        this.id = source["id"];
+	        this.rack = source["rack"];
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.isController = source["isController"];
+	    }
+	}
+	export class BrokerDetails {
+	    broker?: TabBrokersDataBroker;
+	    kafkaVersion: string;
+	    configs: ConfigEntry[];
+	    logDirs: BrokerLogDir[];
+	    apiVersions: BrokerAPIVersion[];
+	    totalSize: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BrokerDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.broker = this.convertValues(source["broker"], TabBrokersDataBroker);
+	        this.kafkaVersion = source["kafkaVersion"];
+	        this.configs = this.convertValues(source["configs"], ConfigEntry);
+	        this.logDirs = this.convertValues(source["logDirs"], BrokerLogDir);
+	        this.apiVersions = this.convertValues(source["apiVersions"], BrokerAPIVersion);
+	        this.totalSize = source["totalSize"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -267,36 +424,80 @@ export namespace kafka {
 		    return a;
 		}
 	}
-	export class TabTopicsDataTopic {
+	export class ConfigChange {
 	    name: string;
-	    partitionCount: number;
-	    messageCount: number;
+	    value: string;
+	    isDeleted: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabTopicsDataTopic(source);
+	        return new ConfigChange(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.partitionCount = source["partitionCount"];
-	        this.messageCount = source["messageCount"];
+	        this.value = source["value"];
+	        this.isDeleted = source["isDeleted"];
 	    }
 	}
-	export class TabTopicsData {
-	    isConnected: boolean;
-	    count: number;
-	    list: TabTopicsDataTopic[];
+	export class ConsumerGroupPartition {
+	    topic: string;
+	    partitionID: number;
+	    committedOffset: number;
+	    logEndOffset: number;
+	    lag: number;
+	    memberID: string;
+	    clientID: string;
+	    error: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabTopicsData(source);
+	        return new ConsumerGroupPartition(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.isConnected = source["isConnected"];
-	        this.count = source["count"];
-	        this.list = this.convertValues(source["list"], TabTopicsDataTopic);
+	        this.topic = source["topic"];
+	        this.partitionID = source["partitionID"];
+	        this.committedOffset = source["committedOffset"];
+	        this.logEndOffset = source["logEndOffset"];
+	        this.lag = source["lag"];
+	        this.memberID = source["memberID"];
+	        this.clientID = source["clientID"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ConsumerGroupAssignment {
+	    topic: string;
+	    partitionIDs: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ConsumerGroupAssignment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.topic = source["topic"];
+	        this.partitionIDs = source["partitionIDs"];
+	    }
+	}
+	export class ConsumerGroupMember {
+	    memberID: string;
+	    instanceID: string;
+	    clientID: string;
+	    clientHost: string;
+	    assignments: ConsumerGroupAssignment[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ConsumerGroupMember(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.memberID = source["memberID"];
+	        this.instanceID = source["instanceID"];
+	        this.clientID = source["clientID"];
+	        this.clientHost = source["clientHost"];
+	        this.assignments = this.convertValues(source["assignments"], ConsumerGroupAssignment);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -317,44 +518,30 @@ export namespace kafka {
 		    return a;
 		}
 	}
-	export class TopicPartition {
-	    id: number;
-	    offsetTotalStart: number;
-	    offsetTotalEnd: number;
-	    offsetCurrentStart: number;
-	    offsetCurrentEnd: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new TopicPartition(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.offsetTotalStart = source["offsetTotalStart"];
-	        this.offsetTotalEnd = source["offsetTotalEnd"];
-	        this.offsetCurrentStart = source["offsetCurrentStart"];
-	        this.offsetCurrentEnd = source["offsetCurrentEnd"];
-	    }
-	}
-	export class TopicOutput {
-	    topicName: string;
-	    startFromTime: string;
-	    countTotal: number;
-	    countCurrent: number;
-	    partitions: TopicPartition[];
+	export class ConsumerGroup {
+	    name: string;
+	    state: string;
+	    protocolType: string;
+	    protocol: string;
+	    coordinator?: TabBrokersDataBroker;
+	    members: ConsumerGroupMember[];
+	    partitions: ConsumerGroupPartition[];
+	    lagTotal: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new TopicOutput(source);
+	        return new ConsumerGroup(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.topicName = source["topicName"];
-	        this.startFromTime = source["startFromTime"];
-	        this.countTotal = source["countTotal"];
-	        this.countCurrent = source["countCurrent"];
-	        this.partitions = this.convertValues(source["partitions"], TopicPartition);
+	        this.name = source["name"];
+	        this.state = source["state"];
+	        this.protocolType = source["protocolType"];
+	        this.protocol = source["protocol"];
+	        this.coordinator = this.convertValues(source["coordinator"], TabBrokersDataBroker);
+	        this.members = this.convertValues(source["members"], ConsumerGroupMember);
+	        this.partitions = this.convertValues(source["partitions"], ConsumerGroupPartition);
+	        this.lagTotal = source["lagTotal"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -375,61 +562,70 @@ export namespace kafka {
 		    return a;
 		}
 	}
-
-}
-
-export namespace kubernetes {
-	
-	export class State {
-	    id: string;
-	    selectedContext: string;
-	    selectedNamespace: string;
-	    isConnected: boolean;
-	    isPortForwarded: boolean;
-	    currentTab: string;
+	export class ConsumerGroupOffsetsReset {
+	    strategy: string;
+	    topic: string;
+	    partitionIDs: number[];
+	    offset: number;
+	    timestamp: string;
+	    shift: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new State(source);
+	        return new ConsumerGroupOffsetsReset(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.selectedContext = source["selectedContext"];
-	        this.selectedNamespace = source["selectedNamespace"];
-	        this.isConnected = source["isConnected"];
-	        this.isPortForwarded = source["isPortForwarded"];
-	        this.currentTab = source["currentTab"];
+	        this.strategy = source["strategy"];
+	        this.topic = source["topic"];
+	        this.partitionIDs = source["partitionIDs"];
+	        this.offset = source["offset"];
+	        this.timestamp = source["timestamp"];
+	        this.shift = source["shift"];
 	    }
 	}
-	export class TabOverviewDataContext {
-	    isSelected: boolean;
-	    name: string;
-	    cluster: string;
+	export class ConsumerGroupOperationPlanPartition {
+	    topic: string;
+	    partitionID: number;
+	    currentOffset: number;
+	    targetOffset: number;
+	    logStartOffset: number;
+	    logEndOffset: number;
+	    error: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabOverviewDataContext(source);
+	        return new ConsumerGroupOperationPlanPartition(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.isSelected = source["isSelected"];
-	        this.name = source["name"];
-	        this.cluster = source["cluster"];
+	        this.topic = source["topic"];
+	        this.partitionID = source["partitionID"];
+	        this.currentOffset = source["currentOffset"];
+	        this.targetOffset = source["targetOffset"];
+	        this.logStartOffset = source["logStartOffset"];
+	        this.logEndOffset = source["logEndOffset"];
+	        this.error = source["error"];
 	    }
 	}
-	export class TabOverviewData {
-	    isConnected: boolean;
-	    contexts: TabOverviewDataContext[];
+	export class ConsumerGroupOperationPlan {
+	    groupName: string;
+	    operation: string;
+	    confirmationToken: string;
+	    isApplied: boolean;
+	    partitions: ConsumerGroupOperationPlanPartition[];
 	
 	    static createFrom(source: any = {}) {
-	        return new TabOverviewData(source);
+	        return new ConsumerGroupOperationPlan(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.isConnected = source["isConnected"];
-	        this.contexts = this.convertValues(source["contexts"], TabOverviewDataContext);
+	        this.groupName = source["groupName"];
+	        this.operation = source["operation"];
+	        this.confirmationToken = source["confirmationToken"];
+	        this.isApplied = source["isApplied"];
+	        this.partitions = this.convertValues(source["partitions"], ConsumerGroupOperationPlanPartition);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -450,34 +646,1106 @@ export namespace kubernetes {
 		    return a;
 		}
 	}
-	export class TabWorkloadsPodsDataPodPort {
-	    name: string;
-	    containerPort: number;
+	export class MessageHeader {
+	    key: string;
+	    value: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabWorkloadsPodsDataPodPort(source);
+	        return new MessageHeader(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.containerPort = source["containerPort"];
+	        this.key = source["key"];
+	        this.value = source["value"];
 	    }
 	}
-	export class TabWorkloadsPodsDataPod {
-	    name: string;
-	    namespace: string;
-	    ports: TabWorkloadsPodsDataPodPort[];
+	export class ProduceMessage {
+	    partition?: number;
+	    key: string;
+	    value: string;
+	    isNullValue: boolean;
+	    headers: MessageHeader[];
+	    timestamp: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TabWorkloadsPodsDataPod(source);
+	        return new ProduceMessage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.partition = source["partition"];
+	        this.key = source["key"];
+	        this.value = source["value"];
+	        this.isNullValue = source["isNullValue"];
+	        this.headers = this.convertValues(source["headers"], MessageHeader);
+	        this.timestamp = source["timestamp"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProduceOptions {
+	    compression: string;
+	    acks: string;
+	    keySchemaSubject: string;
+	    valueSchemaSubject: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProduceOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.compression = source["compression"];
+	        this.acks = source["acks"];
+	        this.keySchemaSubject = source["keySchemaSubject"];
+	        this.valueSchemaSubject = source["valueSchemaSubject"];
+	    }
+	}
+	export class ProduceResult {
+	    partitionID: number;
+	    offset: number;
+	    timestampFormatted: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProduceResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.partitionID = source["partitionID"];
+	        this.offset = source["offset"];
+	        this.timestampFormatted = source["timestampFormatted"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ProduceOutput {
+	    count: number;
+	    errorCount: number;
+	    results: ProduceResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ProduceOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.errorCount = source["errorCount"];
+	        this.results = this.convertValues(source["results"], ProduceResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SchemaRegistry {
+	    url: string;
+	    username: string;
+	    password: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchemaRegistry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	    }
+	}
+	export class TopicProtoMapping {
+	    topic: string;
+	    keyMessageType: string;
+	    valueMessageType: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicProtoMapping(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.topic = source["topic"];
+	        this.keyMessageType = source["keyMessageType"];
+	        this.valueMessageType = source["valueMessageType"];
+	    }
+	}
+	export class State {
+	    id: string;
+	    address: string;
+	    authMethod?: string;
+	    securityProtocol: string;
+	    saslMechanism: string;
+	    authUsername: string;
+	    authPassword: string;
+	    authToken: string;
+	    tls?: tlsconfig.Settings;
+	    schemaRegistry?: SchemaRegistry;
+	    protoImportPathList: string[];
+	    protoFileList: string[];
+	    protoDescriptorSetList: string[];
+	    topicProtoMappings: TopicProtoMapping[];
+	    consumingBufferSize: number;
+	    isConnected: boolean;
+	    currentTab: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new State(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.address = source["address"];
+	        this.authMethod = source["authMethod"];
+	        this.securityProtocol = source["securityProtocol"];
+	        this.saslMechanism = source["saslMechanism"];
+	        this.authUsername = source["authUsername"];
+	        this.authPassword = source["authPassword"];
+	        this.authToken = source["authToken"];
+	        this.tls = this.convertValues(source["tls"], tlsconfig.Settings);
+	        this.schemaRegistry = this.convertValues(source["schemaRegistry"], SchemaRegistry);
+	        this.protoImportPathList = source["protoImportPathList"];
+	        this.protoFileList = source["protoFileList"];
+	        this.protoDescriptorSetList = source["protoDescriptorSetList"];
+	        this.topicProtoMappings = this.convertValues(source["topicProtoMappings"], TopicProtoMapping);
+	        this.consumingBufferSize = source["consumingBufferSize"];
+	        this.isConnected = source["isConnected"];
+	        this.currentTab = source["currentTab"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TabBrokersData {
+	    isConnected: boolean;
+	    count: number;
+	    list: TabBrokersDataBroker[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TabBrokersData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isConnected = source["isConnected"];
+	        this.count = source["count"];
+	        this.list = this.convertValues(source["list"], TabBrokersDataBroker);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TabConsumersDataConsumer {
+	    name: string;
+	    state: string;
+	    membersCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TabConsumersDataConsumer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.state = source["state"];
+	        this.membersCount = source["membersCount"];
+	    }
+	}
+	export class TabConsumersData {
+	    isConnected: boolean;
+	    count: number;
+	    list: TabConsumersDataConsumer[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TabConsumersData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isConnected = source["isConnected"];
+	        this.count = source["count"];
+	        this.list = this.convertValues(source["list"], TabConsumersDataConsumer);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TabOverviewData {
+	    isConnected: boolean;
+	    clusterID: string;
+	    controllerID: number;
+	    brokerCount: number;
+	    topicCount: number;
+	    partitionCount: number;
+	    underReplicatedPartitionCount: number;
+	    offlinePartitionCount: number;
+	    totalSize: number;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TabOverviewData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isConnected = source["isConnected"];
+	        this.clusterID = source["clusterID"];
+	        this.controllerID = source["controllerID"];
+	        this.brokerCount = source["brokerCount"];
+	        this.topicCount = source["topicCount"];
+	        this.partitionCount = source["partitionCount"];
+	        this.underReplicatedPartitionCount = source["underReplicatedPartitionCount"];
+	        this.offlinePartitionCount = source["offlinePartitionCount"];
+	        this.totalSize = source["totalSize"];
+	        this.error = source["error"];
+	    }
+	}
+	export class TabTopicsDataTopic {
+	    name: string;
+	    partitionCount: number;
+	    messageCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TabTopicsDataTopic(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.partitionCount = source["partitionCount"];
+	        this.messageCount = source["messageCount"];
+	    }
+	}
+	export class TabTopicsData {
+	    isConnected: boolean;
+	    count: number;
+	    list: TabTopicsDataTopic[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TabTopicsData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isConnected = source["isConnected"];
+	        this.count = source["count"];
+	        this.list = this.convertValues(source["list"], TabTopicsDataTopic);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TopicConfigs {
+	    topicName: string;
+	    list: ConfigEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicConfigs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.topicName = source["topicName"];
+	        this.list = this.convertValues(source["list"], ConfigEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JSONPathCondition {
+	    path: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JSONPathCondition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.value = source["value"];
+	    }
+	}
+	export class TopicConsumingFilter {
+	    keyContains: string;
+	    keyRegex: string;
+	    valueContains: string;
+	    valueRegex: string;
+	    valueJSONPaths: JSONPathCondition[];
+	    headers: MessageHeader[];
+	    partitionIDs: number[];
+	    timeTo: string;
+	    offsetTo?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicConsumingFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.keyContains = source["keyContains"];
+	        this.keyRegex = source["keyRegex"];
+	        this.valueContains = source["valueContains"];
+	        this.valueRegex = source["valueRegex"];
+	        this.valueJSONPaths = this.convertValues(source["valueJSONPaths"], JSONPathCondition);
+	        this.headers = this.convertValues(source["headers"], MessageHeader);
+	        this.partitionIDs = source["partitionIDs"];
+	        this.timeTo = source["timeTo"];
+	        this.offsetTo = source["offsetTo"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TopicCreation {
+	    name: string;
+	    partitionCount: number;
+	    replicationFactor: number;
+	    configs: {[key: string]: string};
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicCreation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.partitionCount = source["partitionCount"];
+	        this.replicationFactor = source["replicationFactor"];
+	        this.configs = source["configs"];
+	    }
+	}
+	export class TopicPartitionDetails {
+	    id: number;
+	    leader: number;
+	    leaderEpoch: number;
+	    replicas: number[];
+	    isr: number[];
+	    offlineReplicas: number[];
+	    isOffline: boolean;
+	    isUnderReplicated: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicPartitionDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.leader = source["leader"];
+	        this.leaderEpoch = source["leaderEpoch"];
+	        this.replicas = source["replicas"];
+	        this.isr = source["isr"];
+	        this.offlineReplicas = source["offlineReplicas"];
+	        this.isOffline = source["isOffline"];
+	        this.isUnderReplicated = source["isUnderReplicated"];
+	        this.error = source["error"];
+	    }
+	}
+	export class TopicDetails {
+	    name: string;
+	    id: string;
+	    isInternal: boolean;
+	    partitions: TopicPartitionDetails[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.id = source["id"];
+	        this.isInternal = source["isInternal"];
+	        this.partitions = this.convertValues(source["partitions"], TopicPartitionDetails);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TopicPartition {
+	    id: number;
+	    offsetTotalStart: number;
+	    offsetTotalEnd: number;
+	    offsetCurrentStart: number;
+	    offsetCurrentEnd: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicPartition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.offsetTotalStart = source["offsetTotalStart"];
+	        this.offsetTotalEnd = source["offsetTotalEnd"];
+	        this.offsetCurrentStart = source["offsetCurrentStart"];
+	        this.offsetCurrentEnd = source["offsetCurrentEnd"];
+	    }
+	}
+	export class TopicOutput {
+	    topicName: string;
+	    startFromTime: string;
+	    countTotal: number;
+	    countCurrent: number;
+	    partitions: TopicPartition[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.topicName = source["topicName"];
+	        this.startFromTime = source["startFromTime"];
+	        this.countTotal = source["countTotal"];
+	        this.countCurrent = source["countCurrent"];
+	        this.partitions = this.convertValues(source["partitions"], TopicPartition);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MessageSchema {
+	    id: number;
+	    type: string;
+	    subject: string;
+	    version: number;
+	    messageType: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MessageSchema(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.subject = source["subject"];
+	        this.version = source["version"];
+	        this.messageType = source["messageType"];
+	        this.error = source["error"];
+	    }
+	}
+	export class TopicMessage {
+	    sequence: number;
+	    timestampUnix: number;
+	    timestampFormatted: string;
+	    partitionID: number;
+	    offset: number;
+	    key: string;
+	    // Go type: MessageSchema
+	    keySchema?: any;
+	    data: string;
+	    // Go type: MessageSchema
+	    dataSchema?: any;
+	    headers: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicMessage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sequence = source["sequence"];
+	        this.timestampUnix = source["timestampUnix"];
+	        this.timestampFormatted = source["timestampFormatted"];
+	        this.partitionID = source["partitionID"];
+	        this.offset = source["offset"];
+	        this.key = source["key"];
+	        this.keySchema = this.convertValues(source["keySchema"], null);
+	        this.data = source["data"];
+	        this.dataSchema = this.convertValues(source["dataSchema"], null);
+	        this.headers = source["headers"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TopicMessagesPage {
+	    messages: TopicMessage[];
+	    hasMore: boolean;
+	    bufferedCount: number;
+	    droppedCount: number;
+	    topic?: TopicOutput;
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicMessagesPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.messages = this.convertValues(source["messages"], TopicMessage);
+	        this.hasMore = source["hasMore"];
+	        this.bufferedCount = source["bufferedCount"];
+	        this.droppedCount = source["droppedCount"];
+	        this.topic = this.convertValues(source["topic"], TopicOutput);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+
+}
+
+export namespace kubernetes {
+	
+	export class State {
+	    id: string;
+	    selectedContext: string;
+	    selectedNamespace: string;
+	    isConnected: boolean;
+	    isPortForwarded: boolean;
+	    currentTab: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new State(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.selectedContext = source["selectedContext"];
+	        this.selectedNamespace = source["selectedNamespace"];
+	        this.isConnected = source["isConnected"];
+	        this.isPortForwarded = source["isPortForwarded"];
+	        this.currentTab = source["currentTab"];
+	    }
+	}
+	export class TabOverviewDataContext {
+	    isSelected: boolean;
+	    name: string;
+	    cluster: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TabOverviewDataContext(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isSelected = source["isSelected"];
+	        this.name = source["name"];
+	        this.cluster = source["cluster"];
+	    }
+	}
+	export class TabOverviewData {
+	    isConnected: boolean;
+	    contexts: TabOverviewDataContext[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TabOverviewData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isConnected = source["isConnected"];
+	        this.contexts = this.convertValues(source["contexts"], TabOverviewDataContext);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TabWorkloadsPodsDataPodPort {
+	    name: string;
+	    containerPort: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TabWorkloadsPodsDataPodPort(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.containerPort = source["containerPort"];
+	    }
+	}
+	export class TabWorkloadsPodsDataPod {
+	    name: string;
+	    namespace: string;
+	    ports: TabWorkloadsPodsDataPodPort[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TabWorkloadsPodsDataPod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.namespace = source["namespace"];
+	        this.ports = this.convertValues(source["ports"], TabWorkloadsPodsDataPodPort);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TabWorkloadsPodsData {
+	    pods: TabWorkloadsPodsDataPod[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TabWorkloadsPodsData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pods = this.convertValues(source["pods"], TabWorkloadsPodsDataPod);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace project {
+	
+	export class Project {
+	    id: string;
+	    type: string;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Project(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.name = source["name"];
+	    }
+	}
+	export class Stats {
+	    grpcProjectCount: number;
+	    thriftProjectCount: number;
+	    kafkaProjectCount: number;
+	    kubernetesProjectCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Stats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.grpcProjectCount = source["grpcProjectCount"];
+	        this.thriftProjectCount = source["thriftProjectCount"];
+	        this.kafkaProjectCount = source["kafkaProjectCount"];
+	        this.kubernetesProjectCount = source["kubernetesProjectCount"];
+	    }
+	}
+	export class Module {
+	    stats?: Stats;
+	    projects: {[key: string]: Project};
+	    openedProjectIDs: string[];
+	    currentProjectID: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Module(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.stats = this.convertValues(source["stats"], Stats);
+	        this.projects = source["projects"];
+	        this.openedProjectIDs = source["openedProjectIDs"];
+	        this.currentProjectID = source["currentProjectID"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace thrift {
+	
+	export class ClientSettings {
+	    timeoutMs: number;
+	    retries: number;
+	    proxyURL: string;
+	    keepAlive: boolean;
+	    http2: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClientSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.timeoutMs = source["timeoutMs"];
+	        this.retries = source["retries"];
+	        this.proxyURL = source["proxyURL"];
+	        this.keepAlive = source["keepAlive"];
+	        this.http2 = source["http2"];
+	    }
+	}
+	export class EnvironmentVariable {
+	    id: string;
+	    key: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EnvironmentVariable(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.key = source["key"];
+	        this.value = source["value"];
+	    }
+	}
+	export class Environment {
+	    id: string;
+	    name: string;
+	    variables: EnvironmentVariable[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Environment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.variables = this.convertValues(source["variables"], EnvironmentVariable);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ConstantSchema {
+	    name: string;
+	    type: string;
+	    value: any;
+	    doc: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConstantSchema(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	        this.doc = source["doc"];
+	    }
+	}
+	export class TypedefSchema {
+	    name: string;
+	    target: string;
+	    doc: string;
+	    annotations: {[key: string]: string};
+	
+	    static createFrom(source: any = {}) {
+	        return new TypedefSchema(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.namespace = source["namespace"];
-	        this.ports = this.convertValues(source["ports"], TabWorkloadsPodsDataPodPort);
+	        this.target = source["target"];
+	        this.doc = source["doc"];
+	        this.annotations = source["annotations"];
+	    }
+	}
+	export class EnumItemSchema {
+	    name: string;
+	    value: number;
+	    doc: string;
+	    annotations: {[key: string]: string};
+	
+	    static createFrom(source: any = {}) {
+	        return new EnumItemSchema(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.value = source["value"];
+	        this.doc = source["doc"];
+	        this.annotations = source["annotations"];
+	    }
+	}
+	export class EnumSchema {
+	    name: string;
+	    doc: string;
+	    annotations: {[key: string]: string};
+	    items: EnumItemSchema[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EnumSchema(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.doc = source["doc"];
+	        this.annotations = source["annotations"];
+	        this.items = this.convertValues(source["items"], EnumItemSchema);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -498,16 +1766,48 @@ export namespace kubernetes {
 		    return a;
 		}
 	}
-	export class TabWorkloadsPodsData {
-	    pods: TabWorkloadsPodsDataPod[];
+	export class FieldSchema {
+	    id: number;
+	    name: string;
+	    type: string;
+	    required: boolean;
+	    default: any;
+	    doc: string;
+	    annotations: {[key: string]: string};
 	
 	    static createFrom(source: any = {}) {
-	        return new TabWorkloadsPodsData(source);
+	        return new FieldSchema(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.pods = this.convertValues(source["pods"], TabWorkloadsPodsDataPod);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.required = source["required"];
+	        this.default = source["default"];
+	        this.doc = source["doc"];
+	        this.annotations = source["annotations"];
+	    }
+	}
+	export class StructSchema {
+	    name: string;
+	    kind: string;
+	    doc: string;
+	    annotations: {[key: string]: string};
+	    fields: FieldSchema[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StructSchema(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.doc = source["doc"];
+	        this.annotations = source["annotations"];
+	        this.fields = this.convertValues(source["fields"], FieldSchema);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -528,61 +1828,172 @@ export namespace kubernetes {
 		    return a;
 		}
 	}
-
-}
-
-export namespace project {
+	export class FileSchema {
+	    path: string;
+	    structs: StructSchema[];
+	    enums: EnumSchema[];
+	    typedefs: TypedefSchema[];
+	    constants: ConstantSchema[];
 	
-	export class Project {
-	    id: string;
-	    type: string;
+	    static createFrom(source: any = {}) {
+	        return new FileSchema(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.structs = this.convertValues(source["structs"], StructSchema);
+	        this.enums = this.convertValues(source["enums"], EnumSchema);
+	        this.typedefs = this.convertValues(source["typedefs"], TypedefSchema);
+	        this.constants = this.convertValues(source["constants"], ConstantSchema);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FunctionSchema {
+	    serviceName: string;
 	    name: string;
+	    doc: string;
+	    serviceDoc: string;
+	    isOneWay: boolean;
+	    annotations: {[key: string]: string};
+	    arguments: FieldSchema[];
+	    resultType: string;
+	    exceptions: FieldSchema[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Project(source);
+	        return new FunctionSchema(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.type = source["type"];
+	        this.serviceName = source["serviceName"];
 	        this.name = source["name"];
+	        this.doc = source["doc"];
+	        this.serviceDoc = source["serviceDoc"];
+	        this.isOneWay = source["isOneWay"];
+	        this.annotations = source["annotations"];
+	        this.arguments = this.convertValues(source["arguments"], FieldSchema);
+	        this.resultType = source["resultType"];
+	        this.exceptions = this.convertValues(source["exceptions"], FieldSchema);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class Stats {
-	    grpcProjectCount: number;
-	    thriftProjectCount: number;
-	    kafkaProjectCount: number;
-	    kubernetesProjectCount: number;
+	export class Header {
+	    id: string;
+	    key: string;
+	    value: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Stats(source);
+	        return new Header(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.grpcProjectCount = source["grpcProjectCount"];
-	        this.thriftProjectCount = source["thriftProjectCount"];
-	        this.kafkaProjectCount = source["kafkaProjectCount"];
-	        this.kubernetesProjectCount = source["kubernetesProjectCount"];
+	        this.id = source["id"];
+	        this.key = source["key"];
+	        this.value = source["value"];
 	    }
 	}
-	export class Module {
-	    stats?: Stats;
-	    projects: {[key: string]: Project};
-	    openedProjectIDs: string[];
-	    currentProjectID: string;
+	export class MockResponse {
+	    functionID: string;
+	    exceptionName: string;
+	    body: string;
+	    latencyMs: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Module(source);
+	        return new MockResponse(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.stats = this.convertValues(source["stats"], Stats);
-	        this.projects = source["projects"];
-	        this.openedProjectIDs = source["openedProjectIDs"];
-	        this.currentProjectID = source["currentProjectID"];
+	        this.functionID = source["functionID"];
+	        this.exceptionName = source["exceptionName"];
+	        this.body = source["body"];
+	        this.latencyMs = source["latencyMs"];
+	    }
+	}
+	export class MockCall {
+	    id: string;
+	    functionID: string;
+	    method: string;
+	    remoteAddr: string;
+	    request: string;
+	    response: string;
+	    error: string;
+	    receivedAt: string;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MockCall(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.functionID = source["functionID"];
+	        this.method = source["method"];
+	        this.remoteAddr = source["remoteAddr"];
+	        this.request = source["request"];
+	        this.response = source["response"];
+	        this.error = source["error"];
+	        this.receivedAt = source["receivedAt"];
+	        this.durationMs = source["durationMs"];
+	    }
+	}
+	export class MockServer {
+	    listenAddress: string;
+	    transport: string;
+	    protocol: string;
+	    isRunning: boolean;
+	    responses: {[key: string]: MockResponse};
+	    calls: MockCall[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MockServer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.listenAddress = source["listenAddress"];
+	        this.transport = source["transport"];
+	        this.protocol = source["protocol"];
+	        this.isRunning = source["isRunning"];
+	        this.responses = source["responses"];
+	        this.calls = this.convertValues(source["calls"], MockCall);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -603,31 +2014,99 @@ export namespace project {
 		    return a;
 		}
 	}
-
-}
-
-export namespace thrift {
+	export class SavedRequest {
+	    id: string;
+	    name: string;
+	    functionID: string;
+	    address: string;
+	    headers: Header[];
+	    request: string;
 	
-	export class Header {
+	    static createFrom(source: any = {}) {
+	        return new SavedRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.functionID = source["functionID"];
+	        this.address = source["address"];
+	        this.headers = this.convertValues(source["headers"], Header);
+	        this.request = source["request"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HistoryEntry {
 	    id: string;
-	    key: string;
-	    value: string;
+	    functionID: string;
+	    address: string;
+	    headers: Header[];
+	    request: string;
+	    environmentName: string;
+	    response: string;
+	    error: string;
+	    sentAt: string;
+	    durationMs: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Header(source);
+	        return new HistoryEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.key = source["key"];
-	        this.value = source["value"];
+	        this.functionID = source["functionID"];
+	        this.address = source["address"];
+	        this.headers = this.convertValues(source["headers"], Header);
+	        this.request = source["request"];
+	        this.environmentName = source["environmentName"];
+	        this.response = source["response"];
+	        this.error = source["error"];
+	        this.sentAt = source["sentAt"];
+	        this.durationMs = source["durationMs"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class ServiceTreeNode {
 	    id: string;
 	    label: string;
 	    selectable: boolean;
+	    isOneWay: boolean;
 	    children: ServiceTreeNode[];
 	
 	    static createFrom(source: any = {}) {
@@ -639,6 +2118,7 @@ export namespace thrift {
 	        this.id = source["id"];
 	        this.label = source["label"];
 	        this.selectable = source["selectable"];
+	        this.isOneWay = source["isOneWay"];
 	        this.children = this.convertValues(source["children"], ServiceTreeNode);
 	    }
 	
@@ -660,13 +2140,65 @@ export namespace thrift {
 		    return a;
 		}
 	}
+	export class SkeletonField {
+	    path: string;
+	    type: string;
+	    required: boolean;
+	    isUnion: boolean;
+	    default: any;
+	    annotations: {[key: string]: string};
+	
+	    static createFrom(source: any = {}) {
+	        return new SkeletonField(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.type = source["type"];
+	        this.required = source["required"];
+	        this.isUnion = source["isUnion"];
+	        this.default = source["default"];
+	        this.annotations = source["annotations"];
+	    }
+	}
+	export class YARPC {
+	    caller: string;
+	    service: string;
+	    routingKey: string;
+	    routingDelegate: string;
+	    shardKey: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new YARPC(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.caller = source["caller"];
+	        this.service = source["service"];
+	        this.routingKey = source["routingKey"];
+	        this.routingDelegate = source["routingDelegate"];
+	        this.shardKey = source["shardKey"];
+	    }
+	}
 	export class Form {
 	    id: string;
 	    address: string;
 	    headers: Header[];
 	    selectedFunctionID: string;
 	    isMultiplexed: boolean;
+	    transport: string;
+	    protocol: string;
+	    scheme: string;
+	    urlPath: string;
+	    tls?: tlsconfig.Settings;
+	    yarpc?: YARPC;
+	    clientSettings?: ClientSettings;
+	    payloadMode: string;
+	    isDebug: boolean;
 	    request: string;
+	    requestFields: SkeletonField[];
 	    response: string;
 	
 	    static createFrom(source: any = {}) {
@@ -680,7 +2212,17 @@ export namespace thrift {
 	        this.headers = this.convertValues(source["headers"], Header);
 	        this.selectedFunctionID = source["selectedFunctionID"];
 	        this.isMultiplexed = source["isMultiplexed"];
+	        this.transport = source["transport"];
+	        this.protocol = source["protocol"];
+	        this.scheme = source["scheme"];
+	        this.urlPath = source["urlPath"];
+	        this.tls = this.convertValues(source["tls"], tlsconfig.Settings);
+	        this.yarpc = this.convertValues(source["yarpc"], YARPC);
+	        this.clientSettings = this.convertValues(source["clientSettings"], ClientSettings);
+	        this.payloadMode = source["payloadMode"];
+	        this.isDebug = source["isDebug"];
 	        this.request = source["request"];
+	        this.requestFields = this.convertValues(source["requestFields"], SkeletonField);
 	        this.response = source["response"];
 	    }
 	
@@ -708,8 +2250,15 @@ export namespace thrift {
 	    forms: {[key: string]: Form};
 	    formIDs: string[];
 	    currentFormID: string;
-	    filePath: string;
+	    filePath?: string;
+	    fileList: string[];
+	    includePathList: string[];
 	    nodes: ServiceTreeNode[];
+	    history: HistoryEntry[];
+	    savedRequests: SavedRequest[];
+	    environments: Environment[];
+	    currentEnvironmentID: string;
+	    mockServer?: MockServer;
 	
 	    static createFrom(source: any = {}) {
 	        return new Project(source);
@@ -723,7 +2272,14 @@ export namespace thrift {
 	        this.formIDs = source["formIDs"];
 	        this.currentFormID = source["currentFormID"];
 	        this.filePath = source["filePath"];
+	        this.fileList = source["fileList"];
+	        this.includePathList = source["includePathList"];
 	        this.nodes = this.convertValues(source["nodes"], ServiceTreeNode);
+	        this.history = this.convertValues(source["history"], HistoryEntry);
+	        this.savedRequests = this.convertValues(source["savedRequests"], SavedRequest);
+	        this.environments = this.convertValues(source["environments"], Environment);
+	        this.currentEnvironmentID = source["currentEnvironmentID"];
+	        this.mockServer = this.convertValues(source["mockServer"], MockServer);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -747,3 +2303,26 @@ export namespace thrift {
 
 }
 
+export namespace tlsconfig {
+	
+	export class Settings {
+	    caCertPath: string;
+	    clientCertPath: string;
+	    clientKeyPath: string;
+	    insecureSkipVerify: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.caCertPath = source["caCertPath"];
+	        this.clientCertPath = source["clientCertPath"];
+	        this.clientKeyPath = source["clientKeyPath"];
+	        this.insecureSkipVerify = source["insecureSkipVerify"];
+	    }
+	}
+
+}
+
@@ -1,38 +1,93 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {thrift} from '../models';
 
 export function AddHeader(arg1:string,arg2:string):Promise<any>;
 
 export function BeautifyRequest(arg1:string,arg2:string):Promise<any>;
 
+export function ClearHistory(arg1:string):Promise<any>;
+
+export function ClearMockCalls(arg1:string):Promise<any>;
+
+export function CreateEnvironment(arg1:string,arg2:string):Promise<any>;
+
 export function CreateNewForm(arg1:string):Promise<any>;
 
 export function CreateNewProject(arg1:string):Promise<any>;
 
+export function DeleteAllFiles(arg1:string):Promise<any>;
+
+export function DeleteEnvironment(arg1:string,arg2:string):Promise<any>;
+
 export function DeleteHeader(arg1:string,arg2:string,arg3:string):Promise<any>;
 
+export function DeleteHistoryEntry(arg1:string,arg2:string):Promise<any>;
+
 export function DeleteProject(arg1:string):Promise<void>;
 
+export function DeleteSavedRequest(arg1:string,arg2:string):Promise<any>;
+
+export function FileSchemas(arg1:string):Promise<Array<any>>;
+
+export function FunctionSchema(arg1:string,arg2:string):Promise<any>;
+
 export function OpenFilePath(arg1:string):Promise<any>;
 
+export function OpenIncludePath(arg1:string):Promise<any>;
+
+export function OpenSavedRequest(arg1:string,arg2:string,arg3:string):Promise<any>;
+
 export function Project(arg1:string):Promise<any>;
 
+export function RemoveFilePath(arg1:string,arg2:string):Promise<any>;
+
 export function RemoveForm(arg1:string,arg2:string):Promise<any>;
 
+export function RemoveIncludePath(arg1:string,arg2:string):Promise<any>;
+
+export function RestoreHistoryEntry(arg1:string,arg2:string,arg3:string):Promise<any>;
+
 export function SaveAddress(arg1:string,arg2:string,arg3:string):Promise<any>;
 
+export function SaveClientSettings(arg1:string,arg2:string,arg3:any):Promise<any>;
+
 export function SaveCurrentFormID(arg1:string,arg2:string):Promise<any>;
 
+export function SaveDebug(arg1:string,arg2:string,arg3:boolean):Promise<any>;
+
+export function SaveEnvironment(arg1:string,arg2:any):Promise<any>;
+
+export function SaveHTTPSettings(arg1:string,arg2:string,arg3:string,arg4:string,arg5:any):Promise<any>;
+
 export function SaveHeaders(arg1:string,arg2:string,arg3:Array<any>):Promise<any>;
 
 export function SaveIsMultiplexed(arg1:string,arg2:string,arg3:boolean):Promise<any>;
 
+export function SaveMockResponse(arg1:string,arg2:any):Promise<any>;
+
+export function SavePayloadMode(arg1:string,arg2:string,arg3:thrift.PayloadMode):Promise<any>;
+
+export function SaveProtocol(arg1:string,arg2:string,arg3:thrift.Protocol):Promise<any>;
+
+export function SaveRequest(arg1:string,arg2:string,arg3:string):Promise<any>;
+
 export function SaveRequestPayload(arg1:string,arg2:string,arg3:string):Promise<any>;
 
 export function SaveSplitterWidth(arg1:string,arg2:number):Promise<any>;
 
+export function SaveTransport(arg1:string,arg2:string,arg3:thrift.Transport):Promise<any>;
+
+export function SaveYARPC(arg1:string,arg2:string,arg3:any):Promise<any>;
+
+export function SelectEnvironment(arg1:string,arg2:string):Promise<any>;
+
 export function SelectFunction(arg1:string,arg2:string,arg3:string):Promise<any>;
 
 export function SendRequest(arg1:string,arg2:string,arg3:string,arg4:string):Promise<any>;
 
+export function StartMockServer(arg1:string,arg2:string,arg3:thrift.Transport,arg4:thrift.Protocol):Promise<any>;
+
+export function StopMockServer(arg1:string):Promise<any>;
+
 export function StopRequest(arg1:string,arg2:string):Promise<any>;
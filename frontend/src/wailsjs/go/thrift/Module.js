@@ -10,6 +10,18 @@ export function BeautifyRequest(arg1, arg2) {
   return window['go']['thrift']['Module']['BeautifyRequest'](arg1, arg2);
 }
 
+export function ClearHistory(arg1) {
+  return window['go']['thrift']['Module']['ClearHistory'](arg1);
+}
+
+export function ClearMockCalls(arg1) {
+  return window['go']['thrift']['Module']['ClearMockCalls'](arg1);
+}
+
+export function CreateEnvironment(arg1, arg2) {
+  return window['go']['thrift']['Module']['CreateEnvironment'](arg1, arg2);
+}
+
 export function CreateNewForm(arg1) {
   return window['go']['thrift']['Module']['CreateNewForm'](arg1);
 }
@@ -18,34 +30,94 @@ export function CreateNewProject(arg1) {
   return window['go']['thrift']['Module']['CreateNewProject'](arg1);
 }
 
+export function DeleteAllFiles(arg1) {
+  return window['go']['thrift']['Module']['DeleteAllFiles'](arg1);
+}
+
+export function DeleteEnvironment(arg1, arg2) {
+  return window['go']['thrift']['Module']['DeleteEnvironment'](arg1, arg2);
+}
+
 export function DeleteHeader(arg1, arg2, arg3) {
   return window['go']['thrift']['Module']['DeleteHeader'](arg1, arg2, arg3);
 }
 
+export function DeleteHistoryEntry(arg1, arg2) {
+  return window['go']['thrift']['Module']['DeleteHistoryEntry'](arg1, arg2);
+}
+
 export function DeleteProject(arg1) {
   return window['go']['thrift']['Module']['DeleteProject'](arg1);
 }
 
+export function DeleteSavedRequest(arg1, arg2) {
+  return window['go']['thrift']['Module']['DeleteSavedRequest'](arg1, arg2);
+}
+
+export function FileSchemas(arg1) {
+  return window['go']['thrift']['Module']['FileSchemas'](arg1);
+}
+
+export function FunctionSchema(arg1, arg2) {
+  return window['go']['thrift']['Module']['FunctionSchema'](arg1, arg2);
+}
+
 export function OpenFilePath(arg1) {
   return window['go']['thrift']['Module']['OpenFilePath'](arg1);
 }
 
+export function OpenIncludePath(arg1) {
+  return window['go']['thrift']['Module']['OpenIncludePath'](arg1);
+}
+
+export function OpenSavedRequest(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['OpenSavedRequest'](arg1, arg2, arg3);
+}
+
 export function Project(arg1) {
   return window['go']['thrift']['Module']['Project'](arg1);
 }
 
+export function RemoveFilePath(arg1, arg2) {
+  return window['go']['thrift']['Module']['RemoveFilePath'](arg1, arg2);
+}
+
 export function RemoveForm(arg1, arg2) {
   return window['go']['thrift']['Module']['RemoveForm'](arg1, arg2);
 }
 
+export function RemoveIncludePath(arg1, arg2) {
+  return window['go']['thrift']['Module']['RemoveIncludePath'](arg1, arg2);
+}
+
+export function RestoreHistoryEntry(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['RestoreHistoryEntry'](arg1, arg2, arg3);
+}
+
 export function SaveAddress(arg1, arg2, arg3) {
   return window['go']['thrift']['Module']['SaveAddress'](arg1, arg2, arg3);
 }
 
+export function SaveClientSettings(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['SaveClientSettings'](arg1, arg2, arg3);
+}
+
 export function SaveCurrentFormID(arg1, arg2) {
   return window['go']['thrift']['Module']['SaveCurrentFormID'](arg1, arg2);
 }
 
+export function SaveDebug(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['SaveDebug'](arg1, arg2, arg3);
+}
+
+export function SaveEnvironment(arg1, arg2) {
+  return window['go']['thrift']['Module']['SaveEnvironment'](arg1, arg2);
+}
+
+export function SaveHTTPSettings(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['thrift']['Module']['SaveHTTPSettings'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function SaveHeaders(arg1, arg2, arg3) {
   return window['go']['thrift']['Module']['SaveHeaders'](arg1, arg2, arg3);
 }
@@ -54,6 +126,22 @@ export function SaveIsMultiplexed(arg1, arg2, arg3) {
   return window['go']['thrift']['Module']['SaveIsMultiplexed'](arg1, arg2, arg3);
 }
 
+export function SaveMockResponse(arg1, arg2) {
+  return window['go']['thrift']['Module']['SaveMockResponse'](arg1, arg2);
+}
+
+export function SavePayloadMode(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['SavePayloadMode'](arg1, arg2, arg3);
+}
+
+export function SaveProtocol(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['SaveProtocol'](arg1, arg2, arg3);
+}
+
+export function SaveRequest(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['SaveRequest'](arg1, arg2, arg3);
+}
+
 export function SaveRequestPayload(arg1, arg2, arg3) {
   return window['go']['thrift']['Module']['SaveRequestPayload'](arg1, arg2, arg3);
 }
@@ -62,6 +150,18 @@ export function SaveSplitterWidth(arg1, arg2) {
   return window['go']['thrift']['Module']['SaveSplitterWidth'](arg1, arg2);
 }
 
+export function SaveTransport(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['SaveTransport'](arg1, arg2, arg3);
+}
+
+export function SaveYARPC(arg1, arg2, arg3) {
+  return window['go']['thrift']['Module']['SaveYARPC'](arg1, arg2, arg3);
+}
+
+export function SelectEnvironment(arg1, arg2) {
+  return window['go']['thrift']['Module']['SelectEnvironment'](arg1, arg2);
+}
+
 export function SelectFunction(arg1, arg2, arg3) {
   return window['go']['thrift']['Module']['SelectFunction'](arg1, arg2, arg3);
 }
@@ -70,6 +170,14 @@ export function SendRequest(arg1, arg2, arg3, arg4) {
   return window['go']['thrift']['Module']['SendRequest'](arg1, arg2, arg3, arg4);
 }
 
+export function StartMockServer(arg1, arg2, arg3, arg4) {
+  return window['go']['thrift']['Module']['StartMockServer'](arg1, arg2, arg3, arg4);
+}
+
+export function StopMockServer(arg1) {
+  return window['go']['thrift']['Module']['StopMockServer'](arg1);
+}
+
 export function StopRequest(arg1, arg2) {
   return window['go']['thrift']['Module']['StopRequest'](arg1, arg2);
 }